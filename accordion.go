@@ -0,0 +1,464 @@
+package tview
+
+import (
+	"github.com/derailed/tcell/v2"
+)
+
+// accordionSection represents one collapsible section of an Accordion.
+type accordionSection struct {
+	Title    string    // The text shown in the section's header.
+	Item     Primitive // The section's content. May be nil.
+	Height   int       // The preferred content height. 0 to share the remaining space.
+	Expanded bool      // Whether or not the content is shown.
+}
+
+// Accordion is a container which stacks titled sections vertically. Each
+// section consists of a one-row header and a primitive which is only shown
+// while the section is expanded. Collapsed sections only show their header.
+// In exclusive mode (see SetExclusive()), expanding a section collapses all
+// others.
+//
+// Expanded sections with a preferred height receive up to that many rows, in
+// the order of the sections. Expanded sections without a preferred height
+// share the rows which remain after all headers have been drawn.
+//
+// When the accordion itself has focus, the following keys can be used:
+//
+//   - Up arrow, "k": Select the previous header.
+//   - Down arrow, "j": Select the next header.
+//   - Home, "g": Select the first header.
+//   - End, "G": Select the last header.
+//   - Enter, Space: Expand or collapse the selected section.
+//   - Tab: Move the focus to the content of the selected section if it is
+//     expanded.
+//
+// When a section's content has focus, all key events are forwarded to it. To
+// return to the headers, set the focus to the accordion (e.g. with
+// Application.SetFocus() from the content's "done" handler). Clicking a header
+// with the mouse selects it, focuses the accordion, and toggles the section.
+type Accordion struct {
+	*Box
+
+	// The sections, from top to bottom.
+	sections []*accordionSection
+
+	// Whether at most one section may be expanded at a time.
+	exclusive bool
+
+	// The index of the selected header.
+	currentSection int
+
+	// The strings shown in front of the titles of expanded and collapsed
+	// sections.
+	expandedIndicator, collapsedIndicator string
+
+	// The style of the headers and of the selected header when the accordion
+	// has focus.
+	headerStyle, headerStyleFocused tcell.Style
+
+	// The vertical positions of the headers as determined during the last call
+	// to Draw(). -1 for headers which were not drawn.
+	headerY []int
+
+	// We keep a reference to the function which allows us to set the focus to
+	// the accordion when the section containing the focus is collapsed.
+	setFocus func(p Primitive)
+
+	// An optional function which is called when a section is expanded or
+	// collapsed.
+	changed func(index int, expanded bool)
+}
+
+// NewAccordion returns a new accordion without any sections.
+func NewAccordion() *Accordion {
+	return &Accordion{
+		Box:                NewBox(),
+		expandedIndicator:  "▼ ",
+		collapsedIndicator: "▶ ",
+		headerStyle: tcell.StyleDefault.
+			Background(Styles.ContrastBackgroundColor).
+			Foreground(Styles.PrimaryTextColor),
+		headerStyleFocused: tcell.StyleDefault.
+			Background(Styles.PrimaryTextColor).
+			Foreground(Styles.ContrastBackgroundColor),
+	}
+}
+
+// AddSection adds a new section at the bottom of the accordion. The title is
+// shown in the section's header and may contain color tags. The item is shown
+// below the header while the section is expanded. If "height" is greater than
+// 0, the item is given up to that many rows. Otherwise, it shares the
+// remaining space with all other expanded sections without a preferred
+// height. In exclusive mode, adding an expanded section collapses all others.
+func (a *Accordion) AddSection(title string, item Primitive, height int, expanded bool) *Accordion {
+	a.sections = append(a.sections, &accordionSection{
+		Title:  title,
+		Item:   item,
+		Height: height,
+	})
+	if expanded {
+		a.SetExpanded(len(a.sections)-1, true)
+	}
+	return a
+}
+
+// RemoveSection removes the section with the given index. Nothing happens if
+// the index is out of range.
+func (a *Accordion) RemoveSection(index int) *Accordion {
+	if index < 0 || index >= len(a.sections) {
+		return a
+	}
+	section := a.sections[index]
+	a.sections = append(a.sections[:index], a.sections[index+1:]...)
+	if a.currentSection > index || a.currentSection >= len(a.sections) {
+		a.currentSection--
+	}
+	if a.currentSection < 0 {
+		a.currentSection = 0
+	}
+	if section.Item != nil && section.Item.HasFocus() && a.setFocus != nil {
+		a.setFocus(a)
+	}
+	return a
+}
+
+// Clear removes all sections.
+func (a *Accordion) Clear() *Accordion {
+	hasFocus := a.HasFocus()
+	a.sections = nil
+	a.currentSection = 0
+	if hasFocus && a.setFocus != nil {
+		a.setFocus(a)
+	}
+	return a
+}
+
+// GetSectionCount returns the number of sections.
+func (a *Accordion) GetSectionCount() int {
+	return len(a.sections)
+}
+
+// GetSection returns the title and the primitive of the section with the
+// given index or ("", nil) if the index is out of range.
+func (a *Accordion) GetSection(index int) (title string, item Primitive) {
+	if index < 0 || index >= len(a.sections) {
+		return "", nil
+	}
+	return a.sections[index].Title, a.sections[index].Item
+}
+
+// SetSectionTitle sets the title of the section with the given index.
+func (a *Accordion) SetSectionTitle(index int, title string) *Accordion {
+	if index >= 0 && index < len(a.sections) {
+		a.sections[index].Title = title
+	}
+	return a
+}
+
+// SetExclusive sets whether at most one section may be expanded at a time
+// (true) or whether sections are expanded and collapsed independently of each
+// other (false, the default). When switching to exclusive mode, only the first
+// expanded section remains expanded.
+func (a *Accordion) SetExclusive(exclusive bool) *Accordion {
+	a.exclusive = exclusive
+	if exclusive {
+		for index, section := range a.sections {
+			if section.Expanded {
+				a.collapseOthers(index)
+				break
+			}
+		}
+	}
+	return a
+}
+
+// SetExpanded expands or collapses the section with the given index. In
+// exclusive mode, expanding a section collapses all others. If the focus is
+// inside a section which is collapsed, the accordion receives the focus.
+func (a *Accordion) SetExpanded(index int, expanded bool) *Accordion {
+	if index < 0 || index >= len(a.sections) {
+		return a
+	}
+	if expanded && a.exclusive {
+		a.collapseOthers(index)
+	}
+	a.setExpanded(index, expanded)
+	return a
+}
+
+// IsExpanded returns whether the section with the given index is expanded.
+func (a *Accordion) IsExpanded(index int) bool {
+	if index < 0 || index >= len(a.sections) {
+		return false
+	}
+	return a.sections[index].Expanded
+}
+
+// SetCurrentSection selects the header of the section with the given index.
+func (a *Accordion) SetCurrentSection(index int) *Accordion {
+	if index >= len(a.sections) {
+		index = len(a.sections) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	a.currentSection = index
+	return a
+}
+
+// GetCurrentSection returns the index of the selected header.
+func (a *Accordion) GetCurrentSection() int {
+	return a.currentSection
+}
+
+// SetIndicators sets the strings shown in front of the titles of expanded and
+// collapsed sections (defaults to "▼ " and "▶ "). They may contain color tags.
+func (a *Accordion) SetIndicators(expanded, collapsed string) *Accordion {
+	a.expandedIndicator = expanded
+	a.collapsedIndicator = collapsed
+	return a
+}
+
+// SetHeaderStyle sets the style of the section headers.
+func (a *Accordion) SetHeaderStyle(style tcell.Style) *Accordion {
+	a.headerStyle = style
+	return a
+}
+
+// SetHeaderStyleFocused sets the style of the selected header while the
+// accordion has focus.
+func (a *Accordion) SetHeaderStyleFocused(style tcell.Style) *Accordion {
+	a.headerStyleFocused = style
+	return a
+}
+
+// SetChangedFunc sets a handler which is called whenever a section is expanded
+// or collapsed, either by the user or by calling SetExpanded(). It receives the
+// index of the section and its new state. In exclusive mode, the handler is
+// also called for each section which is collapsed because another one was
+// expanded.
+func (a *Accordion) SetChangedFunc(handler func(index int, expanded bool)) *Accordion {
+	a.changed = handler
+	return a
+}
+
+// collapseOthers collapses all sections except the one with the given index.
+func (a *Accordion) collapseOthers(index int) {
+	for other := range a.sections {
+		if other != index {
+			a.setExpanded(other, false)
+		}
+	}
+}
+
+// setExpanded sets the state of the section with the given index, moving the
+// focus out of collapsed sections and invoking the "changed" handler.
+func (a *Accordion) setExpanded(index int, expanded bool) {
+	section := a.sections[index]
+	if section.Expanded == expanded {
+		return
+	}
+	section.Expanded = expanded
+	if !expanded && section.Item != nil && section.Item.HasFocus() && a.setFocus != nil {
+		a.setFocus(a)
+	}
+	if a.changed != nil {
+		a.changed(index, expanded)
+	}
+}
+
+// toggle expands the section with the given index if it is collapsed and
+// collapses it otherwise.
+func (a *Accordion) toggle(index int) {
+	a.SetExpanded(index, !a.sections[index].Expanded)
+}
+
+// Draw draws this primitive onto the screen.
+func (a *Accordion) Draw(screen tcell.Screen) {
+	a.Box.DrawForSubclass(screen, a)
+	x, y, width, height := a.GetInnerRect()
+
+	// Distribute the rows which remain after the headers. Preferred heights
+	// are served first, the rest is shared equally.
+	heights := make([]int, len(a.sections))
+	remaining := height - len(a.sections)
+	var flexible int
+	for index, section := range a.sections {
+		if !section.Expanded || section.Item == nil {
+			continue
+		}
+		if section.Height <= 0 {
+			flexible++
+			continue
+		}
+		heights[index] = section.Height
+		if heights[index] > remaining {
+			heights[index] = remaining
+		}
+		if heights[index] < 0 {
+			heights[index] = 0
+		}
+		remaining -= heights[index]
+	}
+	for index, section := range a.sections {
+		if !section.Expanded || section.Item == nil || section.Height > 0 || flexible == 0 || remaining <= 0 {
+			continue
+		}
+		heights[index] = remaining / flexible
+		remaining -= heights[index]
+		flexible--
+	}
+
+	// Draw headers and expanded sections.
+	a.headerY = a.headerY[:0]
+	hasFocus := a.Box.HasFocus()
+	var focused Primitive
+	bottom := y + height
+	for index, section := range a.sections {
+		if y >= bottom || width <= 0 {
+			a.headerY = append(a.headerY, -1)
+			continue
+		}
+		a.headerY = append(a.headerY, y)
+		style := a.headerStyle
+		if hasFocus && index == a.currentSection {
+			style = a.headerStyleFocused
+		}
+		for column := 0; column < width; column++ {
+			screen.SetContent(x+column, y, ' ', nil, style)
+		}
+		indicator := a.collapsedIndicator
+		if section.Expanded {
+			indicator = a.expandedIndicator
+		}
+		printWithStyle(screen, indicator+section.Title, x, y, 0, width, AlignLeft, style, false)
+		y++
+
+		if heights[index] > 0 {
+			section.Item.SetRect(x, y, width, heights[index])
+			if section.Item.HasFocus() {
+				focused = section.Item
+			} else {
+				section.Item.Draw(screen)
+			}
+			y += heights[index]
+		} else if section.Item != nil {
+			section.Item.SetRect(x, y, width, 0) // No space, don't receive mouse events.
+		}
+	}
+
+	// The focused item is drawn last so its popups are on top.
+	if focused != nil {
+		focused.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (a *Accordion) Focus(delegate func(p Primitive)) {
+	a.setFocus = delegate
+	a.Box.Focus(delegate)
+}
+
+// HasFocus returns whether or not this primitive or one of its sections has
+// focus.
+func (a *Accordion) HasFocus() bool {
+	for _, section := range a.sections {
+		if section.Item != nil && section.Item.HasFocus() {
+			return true
+		}
+	}
+	return a.Box.HasFocus()
+}
+
+// InputHandler returns the handler for this primitive.
+func (a *Accordion) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return a.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		a.setFocus = setFocus
+
+		// Forward events to the section which has focus.
+		for _, section := range a.sections {
+			if section.Item != nil && section.Item.HasFocus() {
+				if handler := section.Item.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+				return
+			}
+		}
+		if len(a.sections) == 0 {
+			return
+		}
+
+		switch key := event.Key(); key {
+		case tcell.KeyUp:
+			a.SetCurrentSection(a.currentSection - 1)
+		case tcell.KeyDown:
+			a.SetCurrentSection(a.currentSection + 1)
+		case tcell.KeyHome:
+			a.SetCurrentSection(0)
+		case tcell.KeyEnd:
+			a.SetCurrentSection(len(a.sections) - 1)
+		case tcell.KeyEnter:
+			a.toggle(a.currentSection)
+		case tcell.KeyTab:
+			if section := a.sections[a.currentSection]; section.Expanded && section.Item != nil {
+				setFocus(section.Item)
+			}
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'k':
+				a.SetCurrentSection(a.currentSection - 1)
+			case 'j':
+				a.SetCurrentSection(a.currentSection + 1)
+			case 'g':
+				a.SetCurrentSection(0)
+			case 'G':
+				a.SetCurrentSection(len(a.sections) - 1)
+			case ' ':
+				a.toggle(a.currentSection)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (a *Accordion) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return a.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		if !a.InRect(x, y) {
+			return false, nil
+		}
+		a.setFocus = setFocus
+
+		// Clicks on headers toggle their sections.
+		for index, headerY := range a.headerY {
+			if headerY != y || index >= len(a.sections) {
+				continue
+			}
+			if action == MouseLeftClick {
+				setFocus(a)
+				a.currentSection = index
+				a.toggle(index)
+				consumed = true
+			}
+			return
+		}
+
+		// Pass mouse events along to the expanded section that takes it.
+		for _, section := range a.sections {
+			if !section.Expanded || section.Item == nil {
+				continue
+			}
+			consumed, capture = section.Item.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+
+		// Clicks elsewhere focus the accordion.
+		if action == MouseLeftClick {
+			setFocus(a)
+			consumed = true
+		}
+		return
+	})
+}
@@ -1,6 +1,10 @@
 package tview
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
 	"sync"
 	"time"
 
@@ -43,6 +47,15 @@ const (
 	MouseScrollRight
 )
 
+// Color modes which limit the colors used for drawing (see
+// Application.SetColorMode()).
+const (
+	ColorModeDefault = iota // Use all colors supported by the terminal.
+	ColorMode256            // Use the 256-color palette.
+	ColorMode16             // Use the 16 standard ANSI colors.
+	ColorMode8              // Use the 8 basic ANSI colors.
+)
+
 // queuedUpdate represented the execution of f queued by
 // Application.QueueUpdate(). The "done" channel receives exactly one element
 // after f has executed.
@@ -83,6 +96,14 @@ type Application struct {
 	// Set to true if mouse events are enabled.
 	enableMouse bool
 
+	// Set to true if the screen created by Run() should not use the terminal's
+	// alternate screen buffer.
+	noAlternateScreen bool
+
+	// The minimum screen size below which a message is shown instead of the
+	// root primitive. 0 means no minimum.
+	minRows, minColumns int
+
 	// An optional capture function which receives a key event and returns the
 	// event to be forwarded to the default input handler (nil if nothing should
 	// be forwarded).
@@ -118,6 +139,24 @@ type Application struct {
 	mouseDownX, mouseDownY  int              // The position of the mouse when its button was last pressed.
 	lastMouseClick          time.Time        // The time when a mouse button was last clicked.
 	lastMouseButtons        tcell.ButtonMask // The last mouse button state.
+
+	// An optional function which is called when a panic occurs while handling
+	// an event, executing a queued update, or drawing the screen.
+	errorFunc func(err interface{})
+
+	// The screen which limits colors according to the color mode. nil for
+	// ColorModeDefault.
+	colorScreen *colorModeScreen
+
+	// The signals which cause Run() to stop the application.
+	stopSignals []os.Signal
+
+	// An optional function which is called when the application is stopped,
+	// before the screen is finalized.
+	beforeStop func()
+
+	// Whether Stop() is currently calling the "before stop" function.
+	stopping bool
 }
 
 // NewApplication creates and returns a new application.
@@ -154,6 +193,14 @@ func (a *Application) GetInputCapture() func(event *tcell.EventKey) *tcell.Event
 // forwarded to the appropriate mouse event handler. This function can then
 // choose to forward that event (or a different one) by returning it or stop
 // the event processing by returning a nil mouse event.
+//
+// This is the application-wide counterpart to Box.SetMouseCapture(). The
+// function receives every mouse action before it is routed to any primitive,
+// including the primitive which currently captures the mouse (e.g. during a
+// drag), which makes it suitable for global gestures or logging. A single
+// tcell mouse event may result in several actions (e.g. MouseMove followed by
+// MouseLeftDown), each of which is passed to the function separately.
+// Transforming or swallowing one of them does not affect the others.
 func (a *Application) SetMouseCapture(capture func(event *tcell.EventMouse, action MouseAction) (*tcell.EventMouse, MouseAction)) *Application {
 	a.mouseCapture = capture
 	return a
@@ -193,6 +240,66 @@ func (a *Application) SetScreen(screen tcell.Screen) *Application {
 	return a
 }
 
+// GetScreen returns the tcell.Screen used by the application. It is nil
+// before Run() (unless one was provided with SetScreen()) and after the
+// application has stopped. This is an escape hatch for low-level drawing and
+// capability checks (e.g. screen.Colors() or screen.Size()) not provided by
+// tview.
+//
+// The screen is not safe for concurrent use. Only access it from the main
+// goroutine, e.g. in event handlers or in functions passed to QueueUpdate().
+// Do not call this function from within a Draw() function or from a handler
+// set with SetBeforeDrawFunc() or SetAfterDrawFunc(), as the application is
+// locked during drawing. These functions receive the screen as an argument
+// instead. Note that direct drawing is overwritten by the next redraw unless
+// it is done in such a handler.
+func (a *Application) GetScreen() tcell.Screen {
+	a.RLock()
+	defer a.RUnlock()
+	return a.screen
+}
+
+// Screenshot returns the text currently shown on the application's screen, one
+// line per row, separated by newline characters. Colors and attributes are
+// discarded. See ScreenshotANSI() for a version which keeps them. An error is
+// returned if the application has no screen, i.e. if it is not running.
+//
+// The text reflects the screen as of the last time it was drawn. Like
+// GetScreen(), this function must be called from the main goroutine, e.g. in
+// an event handler or a function passed to QueueUpdate(), and not from within
+// a Draw() function or a before/after draw handler. For example, to capture
+// the screen to a file when the user presses F12:
+//
+//	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+//		if event.Key() == tcell.KeyF12 {
+//			if text, err := app.Screenshot(); err == nil {
+//				os.WriteFile("screenshot.txt", []byte(text), 0644)
+//			}
+//			return nil
+//		}
+//		return event
+//	})
+func (a *Application) Screenshot() (string, error) {
+	return a.screenshot(false)
+}
+
+// ScreenshotANSI works like Screenshot() but includes colors and attributes as
+// ANSI escape sequences so that the result reproduces the screen when printed
+// to a terminal, e.g. with "cat".
+func (a *Application) ScreenshotANSI() (string, error) {
+	return a.screenshot(true)
+}
+
+// screenshot returns the content of the application's screen.
+func (a *Application) screenshot(ansi bool) (string, error) {
+	a.RLock()
+	defer a.RUnlock()
+	if a.screen == nil {
+		return "", errors.New("application has no screen")
+	}
+	return cellsText(screenCells(a.screen), ansi), nil
+}
+
 // EnableMouse enables mouse events or disables them (if "false" is provided).
 func (a *Application) EnableMouse(enable bool) *Application {
 	a.Lock()
@@ -208,8 +315,48 @@ func (a *Application) EnableMouse(enable bool) *Application {
 	return a
 }
 
+// EnableAlternateScreen sets whether the screen created by Run() uses the
+// terminal's alternate screen buffer (the default). If disabled, the
+// application draws onto the regular terminal screen and the last drawn frame
+// remains visible (and ends up in the terminal's scrollback) after Stop(). The
+// application still occupies the entire terminal window and overwrites what
+// was visible before. When the application stops, the cursor is placed at the
+// beginning of the last row, so it is common to leave that row empty.
+//
+// This must be called before Run() and has no effect if a screen was provided
+// with SetScreen(). Disabling the alternate screen requires a terminfo-based
+// terminal (i.e. it is not available on the Windows console), Run() returns an
+// error otherwise.
+func (a *Application) EnableAlternateScreen(enable bool) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.noAlternateScreen = !enable
+	return a
+}
+
+// SetMinSize sets the minimum size of the screen required by the application.
+// If the screen is smaller, a centered message such as "Terminal too small
+// (need 80x24)" is drawn instead of the root primitive, until the terminal is
+// resized to be large enough. Key and mouse events are still forwarded to the
+// primitives. A value of 0 means there is no minimum for the respective
+// dimension (the default).
+func (a *Application) SetMinSize(rows, columns int) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.minRows, a.minColumns = rows, columns
+	return a
+}
+
 // Run starts the application and thus the event loop. This function returns
 // when Stop() was called.
+//
+// While running, the application stops (as if Stop() was called) when it
+// receives one of the signals set with SetStopSignals(), if any. Note that
+// pressing Ctrl-C does not send SIGINT because the terminal is in raw mode. It
+// is delivered as a key event instead which also stops the application unless
+// it is intercepted (see SetInputCapture()). Window size changes (SIGWINCH)
+// are handled by the screen and result in a redraw. All other signals are
+// left to the Go runtime's default behavior or to the program's own handlers.
 func (a *Application) Run() error {
 	var (
 		err         error
@@ -220,7 +367,11 @@ func (a *Application) Run() error {
 
 	// Make a screen if there is none yet.
 	if a.screen == nil {
-		a.screen, err = tcell.NewScreen()
+		if a.noAlternateScreen {
+			a.screen, err = newInlineScreen()
+		} else {
+			a.screen, err = tcell.NewScreen()
+		}
 		if err != nil {
 			a.Unlock()
 			return err
@@ -244,9 +395,25 @@ func (a *Application) Run() error {
 		}
 	}()
 
+	// Stop when one of the stop signals is received.
+	if len(a.stopSignals) > 0 {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, a.stopSignals...)
+		defer signal.Stop(signals)
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-signals:
+				a.Stop()
+			case <-done:
+			}
+		}()
+	}
+
 	// Draw the screen for the first time.
 	a.Unlock()
-	a.draw()
+	a.safely(func() { a.draw() })
 
 	// Separate loop to wait for screen events.
 	var wg sync.WaitGroup
@@ -292,7 +459,7 @@ func (a *Application) Run() error {
 			if enableMouse {
 				screen.EnableMouse()
 			}
-			a.draw()
+			a.safely(func() { a.draw() })
 		}
 	}()
 
@@ -305,75 +472,77 @@ EventLoop:
 				break EventLoop
 			}
 
-			switch event := event.(type) {
-			case *tcell.EventKey:
-				a.RLock()
-				root := a.root
-				inputCapture := a.inputCapture
-				a.RUnlock()
-
-				// Intercept keys.
-				var draw bool
-				if inputCapture != nil {
-					event = inputCapture(event)
-					if event == nil {
-						a.draw()
-						continue // Don't forward event.
+			a.safely(func() {
+				switch event := event.(type) {
+				case *tcell.EventKey:
+					a.RLock()
+					root := a.root
+					inputCapture := a.inputCapture
+					a.RUnlock()
+
+					// Intercept keys.
+					var draw bool
+					if inputCapture != nil {
+						event = inputCapture(event)
+						if event == nil {
+							a.draw()
+							return // Don't forward event.
+						}
+						draw = true
 					}
-					draw = true
-				}
 
-				// Ctrl-C closes the application.
-				if event.Key() == tcell.KeyCtrlC {
-					a.Stop()
-				}
+					// Ctrl-C closes the application.
+					if event.Key() == tcell.KeyCtrlC {
+						a.Stop()
+					}
 
-				// Pass other key events to the root primitive.
-				if root != nil && root.HasFocus() {
-					if handler := root.InputHandler(); handler != nil {
-						handler(event, func(p Primitive) {
-							a.SetFocus(p)
-						})
-						draw = true
+					// Pass other key events to the root primitive.
+					if root != nil && root.HasFocus() {
+						if handler := root.InputHandler(); handler != nil {
+							handler(event, func(p Primitive) {
+								a.SetFocus(p)
+							})
+							draw = true
+						}
 					}
-				}
 
-				// Redraw.
-				if draw {
-					a.draw()
-				}
-			case *tcell.EventResize:
-				if time.Since(lastRedraw) < redrawPause {
-					if redrawTimer != nil {
-						redrawTimer.Stop()
+					// Redraw.
+					if draw {
+						a.draw()
 					}
-					redrawTimer = time.AfterFunc(redrawPause, func() {
-						a.events <- event
-					})
-				}
-				a.RLock()
-				screen := a.screen
-				a.RUnlock()
-				if screen == nil {
-					continue
-				}
-				lastRedraw = time.Now()
-				screen.Clear()
-				a.draw()
-			case *tcell.EventMouse:
-				consumed, isMouseDownAction := a.fireMouseActions(event)
-				if consumed {
+				case *tcell.EventResize:
+					if time.Since(lastRedraw) < redrawPause {
+						if redrawTimer != nil {
+							redrawTimer.Stop()
+						}
+						redrawTimer = time.AfterFunc(redrawPause, func() {
+							a.events <- event
+						})
+					}
+					a.RLock()
+					screen := a.screen
+					a.RUnlock()
+					if screen == nil {
+						return
+					}
+					lastRedraw = time.Now()
+					screen.Clear()
 					a.draw()
+				case *tcell.EventMouse:
+					consumed, isMouseDownAction := a.fireMouseActions(event)
+					if consumed {
+						a.draw()
+					}
+					a.lastMouseButtons = event.Buttons()
+					if isMouseDownAction {
+						a.mouseDownX, a.mouseDownY = event.Position()
+					}
 				}
-				a.lastMouseButtons = event.Buttons()
-				if isMouseDownAction {
-					a.mouseDownX, a.mouseDownY = event.Position()
-				}
-			}
+			})
 
 		// If we have updates, now is the time to execute them.
 		case update := <-a.updates:
-			update.f()
+			a.safely(update.f)
 			update.done <- struct{}{}
 		}
 	}
@@ -385,6 +554,69 @@ EventLoop:
 	return nil
 }
 
+// SetColorMode limits the colors used for drawing to the given palette, one
+// of ColorModeDefault (no limit), ColorMode256, ColorMode16, or ColorMode8.
+// All colors which are not part of the palette, including true colors, are
+// replaced with the closest palette color. This applies to everything drawn
+// by the application, including the before and after draw handlers. It is
+// useful for terminals which render true colors incorrectly.
+func (a *Application) SetColorMode(mode int) *Application {
+	a.Lock()
+	defer a.Unlock()
+	var colors int
+	switch mode {
+	case ColorMode256:
+		colors = 256
+	case ColorMode16:
+		colors = 16
+	case ColorMode8:
+		colors = 8
+	default:
+		a.colorScreen = nil
+		return a
+	}
+	palette := make([]tcell.Color, colors)
+	for index := range palette {
+		palette[index] = tcell.PaletteColor(index)
+	}
+	a.colorScreen = &colorModeScreen{
+		palette: palette,
+		cache:   make(map[tcell.Color]tcell.Color),
+	}
+	return a
+}
+
+// SetErrorFunc installs a function which is called when a panic occurs while
+// the application processes an event, executes a queued update, or draws the
+// screen. The recovered value is passed to the function and the event loop
+// continues. To shut down instead, call Stop() from within the function. The
+// screen will be restored when Run() returns.
+//
+// Without such a function (the default, or provide nil), the screen is
+// finalized and the panic is propagated.
+func (a *Application) SetErrorFunc(handler func(err interface{})) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.errorFunc = handler
+	return a
+}
+
+// safely calls the provided function. If it panics and an error function was
+// installed, the recovered value is passed to the error function.
+func (a *Application) safely(f func()) {
+	a.RLock()
+	errorFunc := a.errorFunc
+	a.RUnlock()
+	if errorFunc != nil {
+		defer func() {
+			if p := recover(); p != nil {
+				errorFunc(p)
+			}
+		}()
+	}
+	f()
+}
+
 // fireMouseActions analyzes the provided mouse event, derives mouse actions
 // from it and then forwards them to the corresponding primitives.
 func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMouseDownAction bool) {
@@ -398,7 +630,9 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 			isMouseDownAction = true
 		}
 
-		// Intercept event.
+		// Intercept event. Use a copy so that a transformed or swallowed event
+		// does not affect the other actions derived from the same event.
+		event := event
 		if a.mouseCapture != nil {
 			event, action = a.mouseCapture(event, action)
 			if event == nil {
@@ -484,15 +718,71 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 	return consumed, isMouseDownAction
 }
 
-// Stop stops the application, causing Run() to return.
+// SetStopSignals sets the signals which cause a running application to stop
+// gracefully, i.e. the function set with SetBeforeStopFunc() is called, the
+// terminal is restored, and Run() returns. By default, no signals are handled
+// and the Go runtime's default behavior applies (which terminates the program
+// without restoring the terminal for SIGINT and SIGTERM). For example:
+//
+//	app.SetStopSignals(os.Interrupt, syscall.SIGTERM)
+//
+// The signals are only handled while Run() is running, in addition to any
+// handlers the program registered with signal.Notify(). Call this function
+// without arguments to remove all stop signals. This must be called before
+// Run().
+func (a *Application) SetStopSignals(signals ...os.Signal) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.stopSignals = signals
+	return a
+}
+
+// SetBeforeStopFunc installs a function which is called whenever the
+// application is stopped while it is running, i.e. when Stop() is called
+// directly, when the user presses Ctrl-C, or when one of the stop signals was
+// received (see SetStopSignals()). It is called before the screen is
+// finalized, e.g. to save the application's state. Provide nil to remove the
+// function.
+//
+// The function is called on the goroutine which called Stop(). When Stop() is
+// triggered by a signal, this is a separate goroutine, so access to shared
+// state must be synchronized. Calling Stop() from within the function has no
+// effect.
+func (a *Application) SetBeforeStopFunc(handler func()) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.beforeStop = handler
+	return a
+}
+
+// Stop stops the application, causing Run() to return. The function set with
+// SetBeforeStopFunc() is called first.
 func (a *Application) Stop() {
 	a.Lock()
+	if a.screen == nil || a.stopping {
+		a.Unlock()
+		return
+	}
+	beforeStop := a.beforeStop
+	if beforeStop != nil {
+		a.stopping = true
+		a.Unlock()
+		beforeStop()
+		a.Lock()
+		a.stopping = false
+	}
 	defer a.Unlock()
 	screen := a.screen
 	if screen == nil {
 		return
 	}
 	a.screen = nil
+	if a.noAlternateScreen {
+		// Place the cursor at the beginning of the last row.
+		_, height := screen.Size()
+		screen.ShowCursor(0, height-1)
+		screen.Show()
+	}
 	screen.Fini()
 	a.screenReplacement <- nil
 }
@@ -579,6 +869,29 @@ func (a *Application) draw() *Application {
 		return a
 	}
 
+	// Limit the colors if requested.
+	if a.colorScreen != nil {
+		a.colorScreen.Screen = screen
+		screen = a.colorScreen
+	}
+
+	// Is the screen large enough?
+	if width, height := screen.Size(); width < a.minColumns || height < a.minRows {
+		columns, rows := a.minColumns, a.minRows
+		if columns <= 0 {
+			columns = width // No minimum, the current width is fine.
+		}
+		if rows <= 0 {
+			rows = height
+		}
+		screen.Clear()
+		screen.HideCursor()
+		message := fmt.Sprintf("Terminal too small (need %dx%d)", columns, rows)
+		Print(screen, message, 0, height/2, width, AlignCenter, Styles.PrimaryTextColor)
+		screen.Show()
+		return a
+	}
+
 	// Resize if requested.
 	if fullscreen && root != nil {
 		width, height := screen.Size()
@@ -628,7 +941,11 @@ func (a *Application) GetBeforeDrawFunc() func(screen tcell.Screen) bool {
 }
 
 // SetAfterDrawFunc installs a callback function which is invoked after the root
-// primitive was drawn during screen updates.
+// primitive was drawn during screen updates. Anything the function draws onto
+// the screen appears on top of all primitives, e.g. a clock or a debug overlay.
+// The function is called on the main goroutine, once per frame, before the
+// screen is shown. It must not call Application.Draw() (see also
+// GetScreen()).
 //
 // Provide nil to uninstall the callback function.
 func (a *Application) SetAfterDrawFunc(handler func(screen tcell.Screen)) *Application {
@@ -741,3 +1058,50 @@ func (a *Application) QueueEvent(event tcell.Event) *Application {
 	a.events <- event
 	return a
 }
+
+// colorModeScreen is a tcell.Screen which replaces all colors which are not
+// part of a palette with the closest palette color.
+type colorModeScreen struct {
+	tcell.Screen
+
+	// The available colors.
+	palette []tcell.Color
+
+	// Colors which were already mapped to palette colors.
+	cache map[tcell.Color]tcell.Color
+}
+
+// mapColor returns the palette color closest to the given color.
+func (c *colorModeScreen) mapColor(color tcell.Color) tcell.Color {
+	if color == tcell.ColorDefault || !color.IsRGB() && int(color-tcell.ColorValid) < len(c.palette) {
+		return color
+	}
+	if mapped, ok := c.cache[color]; ok {
+		return mapped
+	}
+	mapped := tcell.FindColor(color, c.palette)
+	c.cache[color] = mapped
+	return mapped
+}
+
+// SetContent sets the contents of a cell with a style limited to the palette.
+func (c *colorModeScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	fg, bg, _ := style.Decompose()
+	c.Screen.SetContent(x, y, mainc, combc, style.Foreground(c.mapColor(fg)).Background(c.mapColor(bg)))
+}
+
+// SetCell sets the contents of a cell with a style limited to the palette.
+func (c *colorModeScreen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	if len(ch) > 0 {
+		c.SetContent(x, y, ch[0], ch[1:], style)
+	} else {
+		c.SetContent(x, y, ' ', nil, style)
+	}
+}
+
+// Fill fills the screen with the given character and a style limited to the
+// palette.
+func (c *colorModeScreen) Fill(ch rune, style tcell.Style) {
+	fg, bg, _ := style.Decompose()
+	c.Screen.Fill(ch, style.Foreground(c.mapColor(fg)).Background(c.mapColor(bg)))
+}
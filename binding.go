@@ -0,0 +1,97 @@
+package tview
+
+// Binding keeps form items in sync with the values of an application's data
+// model. Each form item is bound to a getter and a setter function. When the
+// user changes an item, its setter is called with the new value. Refresh()
+// pushes the current model values (as returned by the getters) into the items.
+//
+// Binding installs its own "changed" or "selected" handler on each bound item,
+// replacing any handler set previously. Example:
+//
+//	settings := struct {
+//		Name    string
+//		Verbose bool
+//	}{}
+//	name := NewInputField().SetLabel("Name")
+//	verbose := NewCheckbox().SetLabel("Verbose")
+//	binding := NewBinding().
+//		BindText(name, func() string { return settings.Name }, func(text string) { settings.Name = text }).
+//		BindChecked(verbose, func() bool { return settings.Verbose }, func(checked bool) { settings.Verbose = checked }).
+//		Refresh()
+type Binding struct {
+	// Functions which push the model values into the bound items.
+	refreshers []func()
+
+	// Set to true while Refresh() is running so that changes caused by it are
+	// not written back to the model.
+	refreshing bool
+}
+
+// NewBinding returns a new binding without any bound items.
+func NewBinding() *Binding {
+	return &Binding{}
+}
+
+// BindText binds the text of an input field to the model. "get" returns the
+// model value, "set" receives the text whenever the user changes it. The input
+// field's "changed" handler is replaced (see InputField.SetChangedFunc()).
+func (b *Binding) BindText(field *InputField, get func() string, set func(text string)) *Binding {
+	field.SetChangedFunc(func(text string) {
+		if !b.refreshing {
+			set(text)
+		}
+	})
+	b.refreshers = append(b.refreshers, func() {
+		if text := get(); text != field.GetText() {
+			field.SetText(text)
+		}
+	})
+	return b
+}
+
+// BindChecked binds the checked state of a checkbox to the model. "get" returns
+// the model value, "set" receives the new state whenever the user changes it.
+// The checkbox's "changed" handler is replaced (see Checkbox.SetChangedFunc()).
+func (b *Binding) BindChecked(checkbox *Checkbox, get func() bool, set func(checked bool)) *Binding {
+	checkbox.SetChangedFunc(func(label string, checked bool) {
+		if !b.refreshing {
+			set(checked)
+		}
+	})
+	b.refreshers = append(b.refreshers, func() {
+		checkbox.SetChecked(get())
+	})
+	return b
+}
+
+// BindOption binds the index of the selected option of a drop-down to the
+// model. "get" returns the model value, "set" receives the new index (-1 for
+// no selection) whenever the user selects an option. The drop-down's
+// "selected" handler is replaced (see DropDown.SetSelectedFunc()).
+func (b *Binding) BindOption(dropDown *DropDown, get func() int, set func(index int)) *Binding {
+	dropDown.SetSelectedFunc(func(text string, index int) {
+		if !b.refreshing {
+			set(index)
+		}
+	})
+	b.refreshers = append(b.refreshers, func() {
+		if index := get(); index != dropDown.currentOption {
+			dropDown.SetCurrentOption(index)
+		}
+	})
+	return b
+}
+
+// Refresh pushes the current model values into all bound items. Call this
+// after the model was changed by the application. The setters are not called
+// during a refresh.
+func (b *Binding) Refresh() *Binding {
+	b.refreshing = true
+	defer func() {
+		b.refreshing = false
+	}()
+	for _, refresh := range b.refreshers {
+		refresh()
+	}
+	return b
+}
@@ -42,6 +42,10 @@ type Box struct {
 	// The border style.
 	borderStyle tcell.Style
 
+	// Custom runes used to draw the border. A value of 0 means the
+	// corresponding rune in Borders is used.
+	borderHorizontal, borderVertical, borderTopLeft, borderTopRight, borderBottomLeft, borderBottomRight rune
+
 	// The title. Only visible if there is a border, too.
 	title string
 
@@ -222,7 +226,13 @@ func (b *Box) GetInputCapture() func(event *tcell.EventKey) *tcell.EventKey {
 func (b *Box) WrapMouseHandler(mouseHandler func(MouseAction, *tcell.EventMouse, func(p Primitive)) (bool, Primitive)) func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 		if b.mouseCapture != nil {
+			x, y := event.Position()
 			action, event = b.mouseCapture(action, event)
+			if event == nil {
+				// The event was swallowed. Stop it from reaching other
+				// primitives if it happened on this one.
+				return b.InRect(x, y), nil
+			}
 		}
 		if event != nil && mouseHandler != nil {
 			consumed, capture = mouseHandler(action, event, setFocus)
@@ -247,7 +257,9 @@ func (b *Box) MouseHandler() func(action MouseAction, event *tcell.EventMouse, s
 // forwarded to the primitive's default mouse event handler. This function can
 // then choose to forward that event (or a different one) by returning it or
 // returning a nil mouse event, in which case the default handler will not be
-// called.
+// called. A swallowed event which occurred within the box's rectangle counts
+// as consumed, i.e. it is not passed on to other primitives and the screen is
+// redrawn.
 //
 // Providing a nil handler will remove a previously existing handler.
 func (b *Box) SetMouseCapture(capture func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse)) *Box {
@@ -282,6 +294,21 @@ func (b *Box) SetBorder(show bool) *Box {
 	return b
 }
 
+// SetBorderRunes sets the runes used to draw the box's border, e.g. '-', '|',
+// and '+' for terminals which cannot display line graphics. Any rune set to 0
+// falls back to the corresponding rune in the global Borders variable (which
+// is also the default for all runes). The title is drawn over the top edge as
+// usual.
+func (b *Box) SetBorderRunes(horizontal, vertical, topLeft, topRight, bottomLeft, bottomRight rune) *Box {
+	b.borderHorizontal = horizontal
+	b.borderVertical = vertical
+	b.borderTopLeft = topLeft
+	b.borderTopRight = topRight
+	b.borderBottomLeft = bottomLeft
+	b.borderBottomRight = bottomRight
+	return b
+}
+
 // SetBorderColor sets the box's border color.
 func (b *Box) SetBorderColor(color tcell.Color) *Box {
 	b.borderStyle = b.borderStyle.Foreground(color)
@@ -379,6 +406,24 @@ func (b *Box) DrawForSubclass(screen tcell.Screen, p Primitive) {
 		topRight = Borders.TopRight
 		bottomLeft = Borders.BottomLeft
 		bottomRight = Borders.BottomRight
+		if b.borderHorizontal != 0 {
+			horizontal = b.borderHorizontal
+		}
+		if b.borderVertical != 0 {
+			vertical = b.borderVertical
+		}
+		if b.borderTopLeft != 0 {
+			topLeft = b.borderTopLeft
+		}
+		if b.borderTopRight != 0 {
+			topRight = b.borderTopRight
+		}
+		if b.borderBottomLeft != 0 {
+			bottomLeft = b.borderBottomLeft
+		}
+		if b.borderBottomRight != 0 {
+			bottomRight = b.borderBottomRight
+		}
 		for x := b.x + 1; x < b.x+b.width-1; x++ {
 			screen.SetContent(x, b.y, horizontal, nil, border)
 			screen.SetContent(x, b.y+b.height-1, horizontal, nil, border)
@@ -1,6 +1,8 @@
 package tview
 
 import (
+	"time"
+
 	"github.com/derailed/tcell/v2"
 )
 
@@ -13,6 +15,9 @@ type Button struct {
 	// The text to be displayed before the input area.
 	label string
 
+	// An optional icon displayed in front of the label.
+	icon string
+
 	// The label color.
 	labelColor tcell.Color
 
@@ -22,6 +27,21 @@ type Button struct {
 	// The background color when the button is in focus.
 	backgroundColorActivated tcell.Color
 
+	// The label and background colors shown briefly when the button is
+	// pressed. If tcell.ColorDefault, the colors of the focused button are
+	// swapped.
+	labelColorPressed, backgroundColorPressed tcell.Color
+
+	// How long the pressed colors are shown. 0 disables the effect.
+	pressedDuration time.Duration
+
+	// A function which causes the screen to be redrawn, called when the pressed
+	// colors are to be removed.
+	pressedRedraw func()
+
+	// The time until which the pressed colors are shown.
+	pressedUntil time.Time
+
 	// An optional function which is called when the button was selected.
 	selected func()
 
@@ -54,6 +74,28 @@ func (b *Button) GetLabel() string {
 	return b.label
 }
 
+// SetIcon sets an icon, e.g. a symbol or an emoji, which is displayed in front
+// of the label, separated by a space. The icon may contain color tags. Provide
+// an empty string to remove the icon.
+func (b *Button) SetIcon(icon string) *Button {
+	b.icon = icon
+	return b
+}
+
+// GetIcon returns the icon displayed in front of the label.
+func (b *Button) GetIcon() string {
+	return b.icon
+}
+
+// getText returns the text displayed on the button, i.e. the icon followed by
+// the label.
+func (b *Button) getText() string {
+	if b.icon == "" {
+		return b.label
+	}
+	return b.icon + " " + b.label
+}
+
 // SetLabelColor sets the color of the button text.
 func (b *Button) SetLabelColor(color tcell.Color) *Button {
 	b.labelColor = color
@@ -74,6 +116,51 @@ func (b *Button) SetBackgroundColorActivated(color tcell.Color) *Button {
 	return b
 }
 
+// SetPressedFlash enables a brief visual feedback when the button is pressed
+// (with Enter or a mouse click): For the given duration, the button is drawn
+// in its pressed colors (see SetLabelColorPressed() and
+// SetBackgroundColorPressed()). A duration of 0 disables the effect (the
+// default).
+//
+// The button is redrawn with the next redraw after the key or mouse event. To
+// remove the pressed colors after the duration, the provided "redraw" function
+// is called from a separate goroutine. It typically calls Application.Draw().
+func (b *Button) SetPressedFlash(duration time.Duration, redraw func()) *Button {
+	b.pressedDuration = duration
+	b.pressedRedraw = redraw
+	return b
+}
+
+// SetLabelColorPressed sets the color of the button text while the button is
+// shown as pressed (see SetPressedFlash()). If not set (or set to
+// tcell.ColorDefault), the background color of the focused button is used.
+func (b *Button) SetLabelColorPressed(color tcell.Color) *Button {
+	b.labelColorPressed = color
+	return b
+}
+
+// SetBackgroundColorPressed sets the background color of the button while it
+// is shown as pressed (see SetPressedFlash()). If not set (or set to
+// tcell.ColorDefault), the label color of the focused button is used.
+func (b *Button) SetBackgroundColorPressed(color tcell.Color) *Button {
+	b.backgroundColorPressed = color
+	return b
+}
+
+// press shows the pressed colors (if enabled) and calls the "selected"
+// handler.
+func (b *Button) press() {
+	if b.pressedDuration > 0 {
+		b.pressedUntil = time.Now().Add(b.pressedDuration)
+		if b.pressedRedraw != nil {
+			time.AfterFunc(b.pressedDuration, b.pressedRedraw)
+		}
+	}
+	if b.selected != nil {
+		b.selected()
+	}
+}
+
 // SetSelectedFunc sets a handler which is called when the button was selected.
 func (b *Button) SetSelectedFunc(handler func()) *Button {
 	b.selected = handler
@@ -94,12 +181,27 @@ func (b *Button) SetBlurFunc(handler func(key tcell.Key)) *Button {
 
 // Draw draws this primitive onto the screen.
 func (b *Button) Draw(screen tcell.Screen) {
+	// Determine the colors.
+	labelColor, activeBackgroundColor := b.labelColor, b.backgroundColor
+	if b.HasFocus() {
+		labelColor, activeBackgroundColor = b.labelColorActivated, b.backgroundColorActivated
+	}
+	if time.Now().Before(b.pressedUntil) {
+		labelColor, activeBackgroundColor = b.backgroundColorActivated, b.labelColorActivated
+		if b.labelColorPressed != tcell.ColorDefault {
+			labelColor = b.labelColorPressed
+		}
+		if b.backgroundColorPressed != tcell.ColorDefault {
+			activeBackgroundColor = b.backgroundColorPressed
+		}
+	}
+
 	// Draw the box.
 	borderColor := b.GetBorderColor()
 	backgroundColor := b.GetBackgroundColor()
-	if b.HasFocus() {
-		b.SetBackgroundColor(b.backgroundColorActivated)
-		b.SetBorderColor(b.labelColorActivated)
+	if b.HasFocus() || activeBackgroundColor != backgroundColor {
+		b.SetBackgroundColor(activeBackgroundColor)
+		b.SetBorderColor(labelColor)
 		defer func() {
 			b.SetBorderColor(borderColor)
 		}()
@@ -111,11 +213,7 @@ func (b *Button) Draw(screen tcell.Screen) {
 	x, y, width, height := b.GetInnerRect()
 	if width > 0 && height > 0 {
 		y = y + height/2
-		labelColor := b.labelColor
-		if b.HasFocus() {
-			labelColor = b.labelColorActivated
-		}
-		Print(screen, b.label, x, y, width, AlignCenter, labelColor)
+		Print(screen, b.getText(), x, y, width, AlignCenter, labelColor)
 	}
 }
 
@@ -125,9 +223,7 @@ func (b *Button) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 		// Process key event.
 		switch key := event.Key(); key {
 		case tcell.KeyEnter: // Selected.
-			if b.selected != nil {
-				b.selected()
-			}
+			b.press()
 		case tcell.KeyBacktab, tcell.KeyTab, tcell.KeyUp, tcell.KeyDown, tcell.KeyLeft, tcell.KeyRight, tcell.KeyEscape: // Leave. No action.
 			if b.blur != nil {
 				b.blur(key)
@@ -146,9 +242,7 @@ func (b *Button) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 		// Process mouse event.
 		if action == MouseLeftClick {
 			setFocus(b)
-			if b.selected != nil {
-				b.selected()
-			}
+			b.press()
 			consumed = true
 		}
 
@@ -32,6 +32,10 @@ type Checkbox struct {
 	// The text color of the input area.
 	fieldTextColor tcell.Color
 
+	// The background and text color of the input area when the checkbox has
+	// focus. If tcell.ColorDefault, the field colors are inverted instead.
+	fieldBackgroundColorFocused, fieldTextColorFocused tcell.Color
+
 	// The string use to display a checked box.
 	checkedString string
 
@@ -47,6 +51,10 @@ type Checkbox struct {
 	// A callback function set by the Form class and called when the user leaves
 	// this form item.
 	finished func(tcell.Key)
+
+	// The x-coordinate of the right edge of the checkbox field as determined
+	// during the last call to Draw().
+	fieldRight int
 }
 
 // NewCheckbox returns a new input field.
@@ -83,12 +91,20 @@ func (c *Checkbox) GetLabel() string {
 }
 
 // SetLabelWidth sets the screen width of the label. A value of 0 will cause the
-// primitive to use the width of the label string.
+// primitive to use the width of the label string. Setting the same width for
+// several checkboxes aligns their fields, e.g. when stacked in a Flex. Labels
+// longer than this width are truncated.
 func (c *Checkbox) SetLabelWidth(width int) *Checkbox {
 	c.labelWidth = width
 	return c
 }
 
+// GetLabelWidth returns the screen width of the label as set with
+// SetLabelWidth(). A value of 0 means the width of the label string is used.
+func (c *Checkbox) GetLabelWidth() int {
+	return c.labelWidth
+}
+
 // SetLabelColor sets the color of the label.
 func (c *Checkbox) SetLabelColor(color tcell.Color) *Checkbox {
 	c.labelColor = color
@@ -107,6 +123,22 @@ func (c *Checkbox) SetFieldTextColor(color tcell.Color) *Checkbox {
 	return c
 }
 
+// SetFieldBackgroundColorFocused sets the background color of the input area
+// when the checkbox has focus. If not set (or set to tcell.ColorDefault), the
+// field text color is used, i.e. the field colors are inverted.
+func (c *Checkbox) SetFieldBackgroundColorFocused(color tcell.Color) *Checkbox {
+	c.fieldBackgroundColorFocused = color
+	return c
+}
+
+// SetFieldTextColorFocused sets the text color of the input area when the
+// checkbox has focus. If not set (or set to tcell.ColorDefault), the field
+// background color is used, i.e. the field colors are inverted.
+func (c *Checkbox) SetFieldTextColorFocused(color tcell.Color) *Checkbox {
+	c.fieldTextColorFocused = color
+	return c
+}
+
 // SetCheckedString sets the string to be displayed when the checkbox is
 // checked (defaults to "X").
 func (c *Checkbox) SetCheckedString(checked string) *Checkbox {
@@ -124,9 +156,11 @@ func (c *Checkbox) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldT
 	return c
 }
 
-// GetFieldWidth returns this primitive's field width.
+// GetFieldWidth returns this primitive's field width, i.e. the screen width of
+// the checked string (see SetCheckedString()). An unchecked box is drawn with
+// the same width.
 func (c *Checkbox) GetFieldWidth() int {
-	return 1
+	return stringWidth(c.checkedString)
 }
 
 // SetChangedFunc sets a handler which is called when the checked state of this
@@ -182,14 +216,22 @@ func (c *Checkbox) Draw(screen tcell.Screen) {
 	// Draw checkbox.
 	fieldStyle := tcell.StyleDefault.Background(c.fieldBackgroundColor).Foreground(c.fieldTextColor)
 	if c.HasFocus() {
-		fieldStyle = fieldStyle.Background(c.fieldTextColor).Foreground(c.fieldBackgroundColor)
+		background, foreground := c.fieldTextColor, c.fieldBackgroundColor
+		if c.fieldBackgroundColorFocused != tcell.ColorDefault {
+			background = c.fieldBackgroundColorFocused
+		}
+		if c.fieldTextColorFocused != tcell.ColorDefault {
+			foreground = c.fieldTextColorFocused
+		}
+		fieldStyle = fieldStyle.Background(background).Foreground(foreground)
 	}
-	checkboxWidth := stringWidth(c.checkedString)
+	checkboxWidth := c.GetFieldWidth()
 	checkedString := c.checkedString
 	if !c.checked {
 		checkedString = strings.Repeat(" ", checkboxWidth)
 	}
 	printWithStyle(screen, checkedString, x, y, 0, checkboxWidth, AlignLeft, fieldStyle, false)
+	c.fieldRight = x + checkboxWidth
 }
 
 // InputHandler returns the handler for this primitive.
@@ -225,8 +267,8 @@ func (c *Checkbox) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			return false, nil
 		}
 
-		// Process mouse event.
-		if action == MouseLeftClick && y == rectY {
+		// Process mouse event. Clicks on the label or the field toggle the box.
+		if action == MouseLeftClick && y == rectY && x < c.fieldRight {
 			setFocus(c)
 			c.checked = !c.checked
 			if c.changed != nil {
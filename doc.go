@@ -9,6 +9,7 @@ The package implements the following widgets:
 
   - TextView: A scrollable window that display multi-colored text. Text may also
     be highlighted.
+  - Pager: A "less"-like text viewer with search and a status line.
   - Table: A scrollable display of tabular data. Table cells, rows, or columns
     may also be highlighted.
   - TreeView: A scrollable display for hierarchical data. Tree nodes can be
@@ -21,9 +22,16 @@ The package implements the following widgets:
   - Form: Forms composed of input fields, drop down selections, checkboxes, and
     buttons.
   - Modal: A centered window with a text message and one or more buttons.
+  - Wizard: A sequence of steps with Back, Next, and Finish buttons.
+  - MenuBar: A bar of menus which open as popups, with optional submenus.
+  - Image: An image drawn with half-block characters.
+  - VerticalText: A label whose text runs from top to bottom.
+  - Timer: A countdown or stopwatch display.
   - Grid: A grid based layout manager.
   - Flex: A Flexbox based layout manager.
   - Pages: A page based layout manager.
+  - ScrollView: A scrollable window onto a larger primitive.
+  - Accordion: A vertical stack of collapsible, titled sections.
 
 The package also provides Application which is used to poll the event queue and
 draw widgets on screen.
@@ -127,6 +135,8 @@ character that may be used in color or region tags will be recognized. Examples:
 	[[]         will be output as [[] (not an escaped tag)
 
 You can use the Escape() function to insert brackets automatically where needed.
+To assemble longer texts from colors, regions, and user-provided text, use a
+RichTextBuilder which escapes the text and writes the tags for you.
 
 # Styles
 
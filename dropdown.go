@@ -80,6 +80,13 @@ type DropDown struct {
 	// selection.
 	selected func(text string, index int)
 
+	// An optional function which returns the text displayed in the closed
+	// drop-down for the currently selected option.
+	selectedText func(text string, index int) string
+
+	// If set to true, a border is drawn around the open drop-down list.
+	listBorder bool
+
 	dragging bool // Set to true when mouse dragging is in progress.
 }
 
@@ -156,6 +163,26 @@ func (d *DropDown) SetTextOptions(prefix, suffix, currentPrefix, currentSuffix,
 	return d
 }
 
+// SetPlaceholder sets the text to be displayed when no option is currently
+// selected. This is the same as the "noSelection" argument of
+// SetTextOptions().
+func (d *DropDown) SetPlaceholder(text string) *DropDown {
+	d.noSelection = text
+	return d
+}
+
+// SetSelectedTextFunc sets a function which formats the text displayed in the
+// drop-down's field when it is closed and an option is selected, for example
+// to show "Sort: Name" instead of "Name". The function receives the selected
+// option's text and index and returns the text to display (which may contain
+// color tags). The current prefix and suffix set with SetTextOptions() are
+// placed around the returned text. Provide nil to display the option text
+// unchanged.
+func (d *DropDown) SetSelectedTextFunc(handler func(text string, index int) string) *DropDown {
+	d.selectedText = handler
+	return d
+}
+
 // SetLabel sets the text to be displayed before the input area.
 func (d *DropDown) SetLabel(label string) *DropDown {
 	d.label = label
@@ -208,6 +235,36 @@ func (d *DropDown) SetListStyles(unselectedFgColor, unselectedBgColor, selectedF
 	return d
 }
 
+// SetListBackgroundColor sets the background color of the drop-down list.
+func (d *DropDown) SetListBackgroundColor(color tcell.Color) *DropDown {
+	d.list.SetBackgroundColor(color)
+	return d
+}
+
+// SetListSelectedStyle sets the foreground and background color of the
+// selected item in the drop-down list. Attributes are ignored.
+func (d *DropDown) SetListSelectedStyle(style tcell.Style) *DropDown {
+	fg, bg, _ := style.Decompose()
+	d.list.SetSelectedTextColor(fg).SetSelectedBackgroundColor(bg)
+	return d
+}
+
+// SetListBorder sets whether a border is drawn around the open drop-down list.
+// The list grows by two cells in width and height to make room for it. Use
+// SetListBorderColor() to change its color.
+func (d *DropDown) SetListBorder(show bool) *DropDown {
+	d.listBorder = show
+	d.list.SetBorder(show)
+	return d
+}
+
+// SetListBorderColor sets the color of the border around the open drop-down
+// list (see SetListBorder()).
+func (d *DropDown) SetListBorderColor(color tcell.Color) *DropDown {
+	d.list.SetBorderColor(color)
+	return d
+}
+
 // SetFormAttributes sets attributes shared by all form items.
 func (d *DropDown) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
 	d.labelWidth = labelWidth
@@ -237,9 +294,25 @@ func (d *DropDown) GetFieldWidth() int {
 			fieldWidth = width
 		}
 	}
+	if width := TaggedStringWidth(d.closedText()); width > fieldWidth {
+		fieldWidth = width
+	}
 	return fieldWidth
 }
 
+// closedText returns the text displayed in the field when the drop-down is
+// closed: the (formatted) current option or the placeholder text.
+func (d *DropDown) closedText() string {
+	if d.currentOption < 0 || d.currentOption >= len(d.options) {
+		return d.noSelection
+	}
+	text := d.options[d.currentOption].Text
+	if d.selectedText != nil {
+		text = d.selectedText(text, d.currentOption)
+	}
+	return d.currentOptionPrefix + text + d.currentOptionSuffix
+}
+
 // AddOption adds a new selectable option to this drop-down. The "selected"
 // callback is called when this option was selected. It may be nil.
 func (d *DropDown) AddOption(text string, selected func()) *DropDown {
@@ -330,16 +403,8 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	fieldWidth := d.fieldWidth
 	if fieldWidth == 0 {
 		fieldWidth = maxWidth
-		if d.currentOption < 0 {
-			noSelectionWidth := TaggedStringWidth(d.noSelection)
-			if noSelectionWidth > fieldWidth {
-				fieldWidth = noSelectionWidth
-			}
-		} else if d.currentOption < len(d.options) {
-			currentOptionWidth := TaggedStringWidth(d.currentOptionPrefix + d.options[d.currentOption].Text + d.currentOptionSuffix)
-			if currentOptionWidth > fieldWidth {
-				fieldWidth = currentOptionWidth
-			}
+		if closedWidth := TaggedStringWidth(d.closedText()); closedWidth > fieldWidth {
+			fieldWidth = closedWidth
 		}
 	}
 	if rightLimit-x < fieldWidth {
@@ -366,10 +431,7 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 		}
 	} else {
 		color := d.fieldTextColor
-		text := d.noSelection
-		if d.currentOption >= 0 && d.currentOption < len(d.options) {
-			text = d.currentOptionPrefix + d.options[d.currentOption].Text + d.currentOptionSuffix
-		}
+		text := d.closedText()
 		// Just show the current selection.
 		if d.HasFocus() && !d.open {
 			color = d.fieldBackgroundColor
@@ -380,20 +442,14 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	// Draw options list.
 	if d.HasFocus() && d.open {
 		// We prefer to drop down but if there is no space, maybe drop up?
-		lx := x
-		ly := y + 1
 		lwidth := maxWidth
 		lheight := len(d.options)
-		_, sheight := screen.Size()
-		if ly+lheight >= sheight && ly-2 > lheight-ly {
-			ly = y - lheight
-			if ly < 0 {
-				ly = 0
-			}
-		}
-		if ly+lheight >= sheight {
-			lheight = sheight - ly
+		if d.listBorder {
+			lwidth += 2
+			lheight += 2
 		}
+		swidth, sheight := screen.Size()
+		lx, ly, lwidth, lheight := PopupRect(x, y, fieldWidth, 1, lwidth, lheight, PopupBelow, swidth, sheight)
 		d.list.SetRect(lx, ly, lwidth, lheight)
 		d.list.Draw(screen)
 	}
@@ -1,6 +1,8 @@
 package tview
 
 import (
+	"strconv"
+
 	"github.com/derailed/tcell/v2"
 )
 
@@ -55,6 +57,13 @@ type Form struct {
 	// The alignment of the buttons.
 	buttonsAlign int
 
+	// The number of empty cells between two buttons.
+	buttonsGap int
+
+	// If set to true, buttons are stacked on top of each other (one per row)
+	// instead of being placed side by side. Only used in vertical layouts.
+	buttonsVertical bool
+
 	// The number of empty rows between items.
 	itemPadding int
 
@@ -63,6 +72,15 @@ type Form struct {
 	// focus so that the last element that had focus keeps it.
 	focusedElement int
 
+	// The order in which elements receive focus when the user navigates the
+	// form, as element indices (see focusedElement). If nil, elements are
+	// focused in the order they were added.
+	tabOrder []int
+
+	// Whether navigating past the last element focuses the first element and
+	// vice versa.
+	wrapAround bool
+
 	// The label color.
 	labelColor tcell.Color
 
@@ -80,6 +98,18 @@ type Form struct {
 
 	// An optional function which is called when the user hits Escape.
 	cancel func()
+
+	// An optional function which is called when the user navigates past the
+	// first or last element while wrapAround is false.
+	done func(key tcell.Key)
+
+	// An optional function which is called when the user changed the value of
+	// an item.
+	changed func()
+
+	// The values of the items as of the last call to ResetDirty() (or the
+	// first change if ResetDirty() was not called for an item).
+	baseline map[FormItem]string
 }
 
 // NewForm returns a new form.
@@ -89,6 +119,8 @@ func NewForm() *Form {
 	f := &Form{
 		Box:                   box,
 		itemPadding:           1,
+		buttonsGap:            1,
+		wrapAround:            true,
 		labelColor:            Styles.SecondaryTextColor,
 		fieldBackgroundColor:  Styles.ContrastBackgroundColor,
 		fieldTextColor:        Styles.PrimaryTextColor,
@@ -135,12 +167,25 @@ func (f *Form) SetFieldTextColor(color tcell.Color) *Form {
 }
 
 // SetButtonsAlign sets how the buttons align horizontally, one of AlignLeft
-// (the default), AlignCenter, and AlignRight. This is only
+// (the default), AlignCenter, and AlignRight. This is only applied to vertical
+// layouts. Buttons are always laid out (and navigated) in the order in which
+// they were added, e.g. add "Cancel" before "OK" for a right-aligned
+// "Cancel  OK" dialog row.
 func (f *Form) SetButtonsAlign(align int) *Form {
 	f.buttonsAlign = align
 	return f
 }
 
+// SetButtonsGap sets the number of empty cells between two neighboring buttons.
+// The default is 1.
+func (f *Form) SetButtonsGap(gap int) *Form {
+	if gap < 0 {
+		gap = 0
+	}
+	f.buttonsGap = gap
+	return f
+}
+
 // SetButtonBackgroundColor sets the background color of the buttons.
 func (f *Form) SetButtonBackgroundColor(color tcell.Color) *Form {
 	f.buttonBackgroundColor = color
@@ -167,6 +212,110 @@ func (f *Form) SetFocus(index int) *Form {
 	return f
 }
 
+// SetTabOrder sets the order in which the user moves through the form's
+// elements with Tab, Backtab, and the other navigation keys. The slice
+// contains element indices, counting non-button items first and buttons last
+// (as in SetFocus()), e.g. []int{0, 2, 1} focuses the third element after the
+// first one. Elements which are not listed follow the listed ones in the order
+// they were added so that all of them remain reachable. Invalid and duplicate
+// indices are ignored. The order does not affect how the form is drawn.
+//
+// If the form does not have focus, the first element of the order receives
+// focus when the form does (unless changed with SetFocus() afterwards).
+// Provide nil to restore the default order.
+func (f *Form) SetTabOrder(order []int) *Form {
+	f.tabOrder = order
+	if !f.HasFocus() && len(f.items)+len(f.buttons) > 0 {
+		f.focusedElement = f.elementOrder()[0]
+	}
+	return f
+}
+
+// elementOrder returns the indices of all elements in the order in which they
+// receive focus.
+func (f *Form) elementOrder() []int {
+	count := len(f.items) + len(f.buttons)
+	order := make([]int, 0, count)
+	seen := make([]bool, count)
+	for _, index := range f.tabOrder {
+		if index >= 0 && index < count && !seen[index] {
+			order = append(order, index)
+			seen[index] = true
+		}
+	}
+	for index := 0; index < count; index++ {
+		if !seen[index] {
+			order = append(order, index)
+		}
+	}
+	return order
+}
+
+// neighborElement returns the index of the element which receives focus after
+// (direction 1) or before (direction -1) the element with the given index,
+// wrapping around at the ends of the tab order.
+func (f *Form) neighborElement(index, direction int) int {
+	order := f.elementOrder()
+	for position, element := range order {
+		if element == index {
+			return order[(position+direction+len(order))%len(order)]
+		}
+	}
+	return order[0]
+}
+
+// atEdge returns whether no element which can receive focus follows (direction
+// 1) or precedes (direction -1) the element with the given index in the tab
+// order.
+func (f *Form) atEdge(index, direction int) bool {
+	order := f.elementOrder()
+	position := -1
+	for p, element := range order {
+		if element == index {
+			position = p
+			break
+		}
+	}
+	if position < 0 {
+		return false
+	}
+	for p := position + direction; p >= 0 && p < len(order); p += direction {
+		if order[p] >= len(f.items) {
+			return false
+		}
+		if _, ok := f.items[order[p]].(*formSection); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// SetWrapAround sets whether navigating past the last element of the form
+// (with Tab, Enter, or the Down and Right keys) focuses the first element and
+// navigating before the first element (with Backtab, Up, or Left) focuses the
+// last one. This is the default. If set to false, the focus stays on the
+// element and the handler set with SetDoneFunc() is called instead so that it
+// can move the focus out of the form.
+//
+// This does not affect the Escape key, which invokes the handler set with
+// SetCancelFunc(), or buttons: Enter on a button invokes the button's
+// selected function rather than moving on.
+func (f *Form) SetWrapAround(wrapAround bool) *Form {
+	f.wrapAround = wrapAround
+	return f
+}
+
+// SetDoneFunc sets a handler which is called when the user navigates past the
+// last element or before the first element of the form while wrapping around
+// is disabled (see SetWrapAround()). It receives the key which was pressed,
+// e.g. KeyTab or KeyBacktab. The handler typically moves the focus to another
+// primitive, for example with Application.SetFocus(). Otherwise, the focus
+// stays where it was.
+func (f *Form) SetDoneFunc(handler func(key tcell.Key)) *Form {
+	f.done = handler
+	return f
+}
+
 // AddInputField adds an input field to the form. It has a label, an optional
 // initial value, a field width (a value of 0 extends it as far as possible),
 // an optional accept function to validate the item's value (set to nil to
@@ -224,16 +373,43 @@ func (f *Form) AddCheckbox(label string, checked bool, changed func(label string
 	return f
 }
 
+// AddSection adds a section header to the form, a horizontal rule with the
+// given title which visually separates groups of items. The header occupies a
+// row like a regular item but it cannot receive focus and is skipped when
+// navigating the form. An empty title results in a plain horizontal rule.
+//
+// Section headers count as form items, e.g. for GetFormItemCount() and
+// GetFormItem(). Their label is always empty.
+func (f *Form) AddSection(title string) *Form {
+	f.items = append(f.items, newFormSection(title))
+	return f
+}
+
 // AddButton adds a new button to the form. The "selected" function is called
-// when the user selects this button. It may be nil.
+// when the user selects this button. It may be nil. Use GetButton() to style
+// it individually.
 func (f *Form) AddButton(label string, selected func()) *Form {
 	f.buttons = append(f.buttons, NewButton(label).SetSelectedFunc(selected))
 	return f
 }
 
-// GetButton returns the button at the specified 0-based index. Note that
-// buttons have been specially prepared for this form and modifying some of
-// their attributes may have unintended side effects.
+// AddButtonWithIcon adds a new button with an icon displayed in front of its
+// label (see Button.SetIcon()). Otherwise, it behaves like AddButton().
+func (f *Form) AddButtonWithIcon(icon, label string, selected func()) *Form {
+	f.AddButton(label, selected)
+	f.buttons[len(f.buttons)-1].SetIcon(icon)
+	return f
+}
+
+// GetButton returns the button at the specified 0-based index. Its label and
+// icon as well as its colors may be changed to style it individually, e.g.
+//
+//	form.GetButton(form.GetButtonIndex("Delete")).
+//		SetLabelColor(tcell.ColorWhite).
+//		SetBackgroundColor(tcell.ColorRed)
+//
+// Note that buttons have been specially prepared for this form and modifying
+// other attributes, e.g. their handlers, may have unintended side effects.
 func (f *Form) GetButton(index int) *Button {
 	return f.buttons[index]
 }
@@ -353,12 +529,102 @@ func (f *Form) GetFocusedItemIndex() (formItem, button int) {
 }
 
 // SetCancelFunc sets a handler which is called when the user hits the Escape
-// key.
+// key. Without a handler, Escape moves the focus to the first element. This
+// is the same whether or not navigation wraps around (see SetWrapAround()).
 func (f *Form) SetCancelFunc(callback func()) *Form {
 	f.cancel = callback
 	return f
 }
 
+// SetChangedFunc sets a handler which is called whenever the user changes the
+// value of any of the form's items, e.g. by typing into an input field,
+// toggling a checkbox, or selecting a drop-down option. Items' own changed
+// handlers are not affected. Custom form items are supported if they have a
+// GetText() string method. Changes made programmatically are not reported.
+func (f *Form) SetChangedFunc(handler func()) *Form {
+	f.changed = handler
+	return f
+}
+
+// IsDirty returns whether the value of any item differs from its value at the
+// last call to ResetDirty(). Items which were never reset are compared to
+// their value before the user first changed them.
+func (f *Form) IsDirty() bool {
+	for _, item := range f.items {
+		baseline, ok := f.baseline[item]
+		if !ok {
+			continue
+		}
+		if value, ok := formItemValue(item); ok && value != baseline {
+			return true
+		}
+	}
+	return false
+}
+
+// ResetDirty records the current values of all items such that IsDirty()
+// returns false until the user changes one of them, e.g. after saving.
+func (f *Form) ResetDirty() *Form {
+	f.baseline = make(map[FormItem]string)
+	for _, item := range f.items {
+		if value, ok := formItemValue(item); ok {
+			f.baseline[item] = value
+		}
+	}
+	return f
+}
+
+// itemValues returns the current values of all items which have one.
+func (f *Form) itemValues() map[FormItem]string {
+	values := make(map[FormItem]string)
+	for _, item := range f.items {
+		if value, ok := formItemValue(item); ok {
+			values[item] = value
+		}
+	}
+	return values
+}
+
+// checkChanged compares the items' values with the provided previous values
+// and calls the changed handler if any of them differ.
+func (f *Form) checkChanged(previous map[FormItem]string) {
+	var changed bool
+	for item, value := range previous {
+		if current, _ := formItemValue(item); current != value {
+			if f.baseline == nil {
+				f.baseline = make(map[FormItem]string)
+			}
+			if _, ok := f.baseline[item]; !ok {
+				f.baseline[item] = value
+			}
+			changed = true
+		}
+	}
+	if changed && f.changed != nil {
+		f.changed()
+	}
+}
+
+// formItemValue returns a string representation of a form item's value. The
+// second return value is false if the item's value cannot be determined.
+func formItemValue(item FormItem) (string, bool) {
+	switch item := item.(type) {
+	case *InputField:
+		return item.GetText(), true
+	case *Checkbox:
+		if item.IsChecked() {
+			return "true", true
+		}
+		return "false", true
+	case *DropDown:
+		index, _ := item.GetCurrentOption()
+		return strconv.Itoa(index), true
+	case interface{ GetText() string }:
+		return item.GetText(), true
+	}
+	return "", false
+}
+
 // Draw draws this primitive onto the screen.
 func (f *Form) Draw(screen tcell.Screen) {
 	f.Box.DrawForSubclass(screen, f)
@@ -444,11 +710,24 @@ func (f *Form) Draw(screen tcell.Screen) {
 	buttonWidths := make([]int, len(f.buttons))
 	buttonsWidth := 0
 	for index, button := range f.buttons {
-		w := TaggedStringWidth(button.GetLabel()) + 4
+		w := TaggedStringWidth(button.getText()) + 4
 		buttonWidths[index] = w
-		buttonsWidth += w + 1
+		buttonsWidth += w + f.buttonsGap
+	}
+	buttonsWidth -= f.buttonsGap
+	stacked := f.buttonsVertical && !f.horizontal
+	if stacked {
+		// All stacked buttons are as wide as the widest one.
+		buttonsWidth = 0
+		for _, w := range buttonWidths {
+			if w > buttonsWidth {
+				buttonsWidth = w
+			}
+		}
+		for index := range buttonWidths {
+			buttonWidths[index] = buttonsWidth
+		}
 	}
-	buttonsWidth--
 
 	// Where do we place them?
 	if !f.horizontal && x+buttonsWidth < rightLimit {
@@ -498,7 +777,11 @@ func (f *Form) Draw(screen tcell.Screen) {
 			focusedPosition = positions[buttonIndex]
 		}
 
-		x += buttonWidth + 1
+		if stacked {
+			y++
+		} else {
+			x += buttonWidth + f.buttonsGap
+		}
 	}
 
 	// Determine vertical offset based on the position of the focused item.
@@ -550,6 +833,13 @@ func (f *Form) Draw(screen tcell.Screen) {
 
 // Focus is called by the application when the primitive receives focus.
 func (f *Form) Focus(delegate func(p Primitive)) {
+	f.focusElement(delegate, 1)
+}
+
+// focusElement hands the focus on to the element at f.focusedElement. If that
+// element cannot receive focus (e.g. a section header), the next element in
+// the given direction (1 or -1) is focused instead.
+func (f *Form) focusElement(delegate func(p Primitive), direction int) {
 	if len(f.items)+len(f.buttons) == 0 {
 		f.hasFocus = true
 		return
@@ -557,29 +847,52 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 	f.hasFocus = false
 
 	// Hand on the focus to one of our child elements.
-	if f.focusedElement < 0 || f.focusedElement >= len(f.items)+len(f.buttons) {
-		f.focusedElement = 0
+	count := len(f.items) + len(f.buttons)
+	if f.focusedElement < 0 || f.focusedElement >= count {
+		f.focusedElement = f.elementOrder()[0]
+	}
+	for skipped := 0; skipped < count && f.focusedElement < len(f.items); skipped++ {
+		if _, ok := f.items[f.focusedElement].(*formSection); !ok {
+			break
+		}
+		f.focusedElement = f.neighborElement(f.focusedElement, direction)
+	}
+	if f.focusedElement < len(f.items) {
+		if _, ok := f.items[f.focusedElement].(*formSection); ok {
+			// Nothing can receive focus.
+			f.hasFocus = true
+			return
+		}
 	}
 	handler := func(key tcell.Key) {
 		// nolint:exhaustive
 		switch key {
 		// BOZO!!
 		case tcell.KeyTab, tcell.KeyEnter, tcell.KeyDown, tcell.KeyRight:
-			f.focusedElement++
-			f.Focus(delegate)
+			if !f.wrapAround && f.atEdge(f.focusedElement, 1) {
+				if f.done != nil {
+					f.done(key)
+				}
+				return
+			}
+			f.focusedElement = f.neighborElement(f.focusedElement, 1)
+			f.focusElement(delegate, 1)
 		// BOZO!!
 		case tcell.KeyBacktab, tcell.KeyUp, tcell.KeyLeft:
-			f.focusedElement--
-			if f.focusedElement < 0 {
-				f.focusedElement = len(f.items) + len(f.buttons) - 1
+			if !f.wrapAround && f.atEdge(f.focusedElement, -1) {
+				if f.done != nil {
+					f.done(key)
+				}
+				return
 			}
-			f.Focus(delegate)
+			f.focusedElement = f.neighborElement(f.focusedElement, -1)
+			f.focusElement(delegate, -1)
 		case tcell.KeyEscape:
 			if f.cancel != nil {
 				f.cancel()
 			} else {
-				f.focusedElement = 0
-				f.Focus(delegate)
+				f.focusedElement = f.elementOrder()[0]
+				f.focusElement(delegate, 1)
 			}
 		}
 	}
@@ -629,6 +942,9 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			return false, nil
 		}
 
+		// Report changes made with the mouse.
+		defer f.checkChanged(f.itemValues())
+
 		// At the end, update f.focusedElement and prepare current item/button.
 		defer func() {
 			if consumed {
@@ -667,6 +983,8 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 // InputHandler returns the handler for this primitive.
 func (f *Form) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return f.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		defer f.checkChanged(f.itemValues())
+
 		for _, item := range f.items {
 			if item != nil && item.HasFocus() {
 				if handler := item.InputHandler(); handler != nil {
@@ -686,3 +1004,79 @@ func (f *Form) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 		}
 	})
 }
+
+// formSection is a form item which displays a section header. It cannot
+// receive focus.
+type formSection struct {
+	*Box
+
+	// The title of the section.
+	title string
+
+	// The color of the title.
+	titleColor tcell.Color
+
+	// The color of the horizontal rule.
+	lineColor tcell.Color
+}
+
+// newFormSection returns a new section header with the given title.
+func newFormSection(title string) *formSection {
+	return &formSection{
+		Box:        NewBox(),
+		title:      title,
+		titleColor: Styles.SecondaryTextColor,
+		lineColor:  Styles.GraphicsColor,
+	}
+}
+
+// GetLabel returns an empty string. Section headers have no label.
+func (s *formSection) GetLabel() string {
+	return ""
+}
+
+// SetFormAttributes sets the section's colors.
+func (s *formSection) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	s.titleColor = labelColor
+	s.backgroundColor = bgColor
+	return s
+}
+
+// GetFieldWidth returns 0, section headers use all available space.
+func (s *formSection) GetFieldWidth() int {
+	return 0
+}
+
+// SetFinishedFunc does nothing, section headers cannot receive focus.
+func (s *formSection) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
+	return s
+}
+
+// Draw draws this primitive onto the screen.
+func (s *formSection) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+	x, y, width, height := s.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the title, preceded by a short line.
+	lineStyle := tcell.StyleDefault.Background(s.backgroundColor).Foreground(s.lineColor)
+	start := x
+	if s.title != "" && width > 2 {
+		screen.SetContent(x, y, Borders.Horizontal, nil, lineStyle)
+		_, titleWidth := Print(screen, " "+s.title+" ", x+1, y, width-1, AlignLeft, s.titleColor)
+		start = x + 1 + titleWidth
+	}
+
+	// Fill the rest of the row with the line.
+	for column := start; column < x+width; column++ {
+		screen.SetContent(column, y, Borders.Horizontal, nil, lineStyle)
+	}
+}
+
+// MouseHandler returns a handler which ignores all events. Section headers
+// don't react to the mouse.
+func (s *formSection) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return s.WrapMouseHandler(nil)
+}
@@ -2,6 +2,7 @@ package tview
 
 import (
 	"math"
+	"sort"
 
 	"github.com/derailed/tcell/v2"
 )
@@ -14,6 +15,8 @@ type gridItem struct {
 	MinGridWidth, MinGridHeight int       // The minimum grid width/height for which this item is visible.
 	Focus                       bool      // Whether or not this item attracts the layout's focus.
 
+	bordersColor tcell.Color // The color of the item's border. tcell.ColorDefault for the grid's border color.
+
 	visible    bool // Whether or not this item was visible the last time the grid was drawn.
 	x, y, w, h int  // The last position of the item relative to the top-left corner of the grid. Undefined if visible is false.
 }
@@ -56,6 +59,10 @@ type Grid struct {
 
 	// The color of the borders around grid items.
 	bordersColor tcell.Color
+
+	// The color of the border around the item which has focus. If
+	// tcell.ColorDefault, the border is drawn like the other borders.
+	bordersColorFocused tcell.Color
 }
 
 // NewGrid returns a new grid-based layout container with no initial primitives.
@@ -175,6 +182,32 @@ func (g *Grid) SetBordersColor(color tcell.Color) *Grid {
 	return g
 }
 
+// SetBordersColorFocused sets the color of the border around the item which
+// contains the focused primitive, e.g. to indicate focus in a dashboard. It
+// takes precedence over item colors set with SetItemBordersColor(). Provide
+// tcell.ColorDefault (the default) to draw this border like all others.
+func (g *Grid) SetBordersColorFocused(color tcell.Color) *Grid {
+	g.bordersColorFocused = color
+	return g
+}
+
+// SetItemBordersColor sets the color of the border around the given primitive
+// (for all items added for it with AddItem()). Provide tcell.ColorDefault to
+// use the grid's border color again (see SetBordersColor()).
+//
+// Where neighboring items share a border, the border line and its junctions
+// are drawn in the item's color, i.e. specially colored borders are drawn on
+// top of regular ones and the focused item's border (see
+// SetBordersColorFocused()) on top of all others.
+func (g *Grid) SetItemBordersColor(p Primitive, color tcell.Color) *Grid {
+	for _, item := range g.items {
+		if item.Item == p {
+			item.bordersColor = color
+		}
+	}
+	return g
+}
+
 // AddItem adds a primitive and its position to the grid. The top-left corner
 // of the primitive will be located in the top-left corner of the grid cell at
 // the given row and column and will span "rowSpan" rows and "colSpan" columns.
@@ -327,7 +360,6 @@ func (g *Grid) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 func (g *Grid) Draw(screen tcell.Screen) {
 	g.Box.DrawForSubclass(screen, g)
 	x, y, width, height := g.GetInnerRect()
-	screenWidth, screenHeight := screen.Size()
 
 	// Make a list of items which apply.
 	items := make(map[Primitive]*gridItem)
@@ -586,8 +618,8 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		g.columnOffset = to
 	}
 
-	// Draw primitives and borders.
-	borderStyle := tcell.StyleDefault.Background(g.backgroundColor).Foreground(g.bordersColor)
+	// Draw primitives.
+	var bordered []*gridItem
 	for primitive, item := range items {
 		// Final primitive position.
 		if !item.visible {
@@ -627,52 +659,80 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		} else {
 			primitive.Draw(screen)
 		}
+		bordered = append(bordered, item)
+	}
 
-		// Draw border around primitive.
-		if g.borders {
-			for bx := item.x; bx < item.x+item.w; bx++ { // Top/bottom lines.
-				if bx < 0 || bx >= screenWidth {
-					continue
-				}
-				by := item.y - 1
-				if by >= 0 && by < screenHeight {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Horizontal, borderStyle)
-				}
-				by = item.y + item.h
-				if by >= 0 && by < screenHeight {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Horizontal, borderStyle)
-				}
-			}
-			for by := item.y; by < item.y+item.h; by++ { // Left/right lines.
-				if by < 0 || by >= screenHeight {
-					continue
-				}
-				bx := item.x - 1
-				if bx >= 0 && bx < screenWidth {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Vertical, borderStyle)
-				}
-				bx = item.x + item.w
-				if bx >= 0 && bx < screenWidth {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Vertical, borderStyle)
-				}
-			}
-			bx, by := item.x-1, item.y-1 // Top-left corner.
-			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.TopLeft, borderStyle)
-			}
-			bx, by = item.x+item.w, item.y-1 // Top-right corner.
-			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.TopRight, borderStyle)
-			}
-			bx, by = item.x-1, item.y+item.h // Bottom-left corner.
-			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.BottomLeft, borderStyle)
-			}
-			bx, by = item.x+item.w, item.y+item.h // Bottom-right corner.
-			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.BottomRight, borderStyle)
-			}
+	// Draw borders around primitives. Regular borders are drawn first, then
+	// those with their own color, then the focused item's border so that shared
+	// lines and junctions take on the color of the more specific border.
+	if !g.borders {
+		return
+	}
+	bordersColor := func(item *gridItem) (color tcell.Color, layer int) {
+		if item == focus && g.bordersColorFocused != tcell.ColorDefault {
+			return g.bordersColorFocused, 2
+		}
+		if item.bordersColor != tcell.ColorDefault {
+			return item.bordersColor, 1
 		}
+		return g.bordersColor, 0
+	}
+	sort.SliceStable(bordered, func(i, j int) bool {
+		_, layerI := bordersColor(bordered[i])
+		_, layerJ := bordersColor(bordered[j])
+		return layerI < layerJ
+	})
+	for _, item := range bordered {
+		color, _ := bordersColor(item)
+		g.drawBorder(screen, item, tcell.StyleDefault.Background(g.backgroundColor).Foreground(color))
+	}
+}
+
+// drawBorder draws the border around the given item, joining it with any
+// border graphics already on the screen.
+func (g *Grid) drawBorder(screen tcell.Screen, item *gridItem, borderStyle tcell.Style) {
+	screenWidth, screenHeight := screen.Size()
+	for bx := item.x; bx < item.x+item.w; bx++ { // Top/bottom lines.
+		if bx < 0 || bx >= screenWidth {
+			continue
+		}
+		by := item.y - 1
+		if by >= 0 && by < screenHeight {
+			PrintJoinedSemigraphics(screen, bx, by, Borders.Horizontal, borderStyle)
+		}
+		by = item.y + item.h
+		if by >= 0 && by < screenHeight {
+			PrintJoinedSemigraphics(screen, bx, by, Borders.Horizontal, borderStyle)
+		}
+	}
+	for by := item.y; by < item.y+item.h; by++ { // Left/right lines.
+		if by < 0 || by >= screenHeight {
+			continue
+		}
+		bx := item.x - 1
+		if bx >= 0 && bx < screenWidth {
+			PrintJoinedSemigraphics(screen, bx, by, Borders.Vertical, borderStyle)
+		}
+		bx = item.x + item.w
+		if bx >= 0 && bx < screenWidth {
+			PrintJoinedSemigraphics(screen, bx, by, Borders.Vertical, borderStyle)
+		}
+	}
+	bx, by := item.x-1, item.y-1 // Top-left corner.
+	if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
+		PrintJoinedSemigraphics(screen, bx, by, Borders.TopLeft, borderStyle)
+	}
+	bx, by = item.x+item.w, item.y-1 // Top-right corner.
+	if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
+		PrintJoinedSemigraphics(screen, bx, by, Borders.TopRight, borderStyle)
+	}
+	bx, by = item.x-1, item.y+item.h // Bottom-left corner.
+	if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
+		PrintJoinedSemigraphics(screen, bx, by, Borders.BottomLeft, borderStyle)
+	}
+	bx, by = item.x+item.w, item.y+item.h // Bottom-right corner.
+	if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
+		PrintJoinedSemigraphics(screen, bx, by, Borders.BottomRight, borderStyle)
 	}
 }
 
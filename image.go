@@ -0,0 +1,193 @@
+package tview
+
+import (
+	"image"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// Image scaling modes (see Image.SetScaling()).
+const (
+	ImageScaleFit     = iota // Scale to fit the available space, keeping the aspect ratio.
+	ImageScaleStretch        // Scale to fill the available space, ignoring the aspect ratio.
+	ImageScaleNone           // Don't scale, clip the image if it is too large.
+)
+
+// Image displays an image.Image using half-block characters ('▀'). Each
+// screen cell shows two vertically stacked pixels, the upper one in the
+// foreground color and the lower one in the background color. This works in
+// any terminal with true color support. In terminals with fewer colors, the
+// pixel colors are mapped to the closest available colors by tcell.
+//
+// Sixel or other terminal graphics protocols are not supported.
+type Image struct {
+	*Box
+
+	// The image to be displayed. May be nil.
+	image image.Image
+
+	// The scaling mode, one of the ImageScale constants.
+	scaling int
+
+	// The horizontal and vertical alignment of the image within the box.
+	align, verticalAlign int
+
+	// The aspect ratio of a screen cell (cell height divided by cell width).
+	// Used to keep the image's aspect ratio when scaling.
+	cellAspectRatio float64
+}
+
+// NewImage returns a new image primitive without an image.
+func NewImage() *Image {
+	return &Image{
+		Box:             NewBox(),
+		scaling:         ImageScaleFit,
+		align:           AlignCenter,
+		verticalAlign:   AlignCenter,
+		cellAspectRatio: 2,
+	}
+}
+
+// SetImage sets the image to be displayed. Provide nil to remove the image.
+func (i *Image) SetImage(img image.Image) *Image {
+	i.image = img
+	return i
+}
+
+// GetImage returns the image which is currently displayed.
+func (i *Image) GetImage() image.Image {
+	return i.image
+}
+
+// SetScaling sets how the image is scaled to the primitive's inner rectangle,
+// one of the following:
+//
+//   - ImageScaleFit: Scale to fit, keeping the aspect ratio (the default).
+//   - ImageScaleStretch: Scale to fill, ignoring the aspect ratio.
+//   - ImageScaleNone: Don't scale, one pixel per half cell.
+func (i *Image) SetScaling(scaling int) *Image {
+	i.scaling = scaling
+	return i
+}
+
+// SetAlign sets the horizontal and vertical alignment of the image if it does
+// not fill the entire inner rectangle. "align" is one of AlignLeft,
+// AlignCenter (the default), or AlignRight. "verticalAlign" uses the same
+// constants where AlignLeft means top and AlignRight means bottom.
+func (i *Image) SetAlign(align, verticalAlign int) *Image {
+	i.align, i.verticalAlign = align, verticalAlign
+	return i
+}
+
+// SetCellAspectRatio sets the ratio of a screen cell's height to its width.
+// This is used to keep the image's aspect ratio with ImageScaleFit. The
+// default is 2, which is typical for most terminal fonts. Values less than or
+// equal to 0 are ignored.
+func (i *Image) SetCellAspectRatio(ratio float64) *Image {
+	if ratio > 0 {
+		i.cellAspectRatio = ratio
+	}
+	return i
+}
+
+// Draw draws this primitive onto the screen.
+func (i *Image) Draw(screen tcell.Screen) {
+	i.Box.DrawForSubclass(screen, i)
+	if i.image == nil {
+		return
+	}
+
+	x, y, width, height := i.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	bounds := i.image.Bounds()
+	imageWidth, imageHeight := bounds.Dx(), bounds.Dy()
+	if imageWidth <= 0 || imageHeight <= 0 {
+		return
+	}
+
+	// Determine the size of the image in pixels, two pixels per cell
+	// vertically.
+	pixelsWidth, pixelsHeight := width, 2*height
+	switch i.scaling {
+	case ImageScaleNone:
+		pixelsWidth, pixelsHeight = imageWidth, imageHeight
+	case ImageScaleFit:
+		// One pixel is cellAspectRatio/2 times as tall as it is wide.
+		pixelAspect := i.cellAspectRatio / 2
+		fitWidth := float64(pixelsHeight) * pixelAspect * float64(imageWidth) / float64(imageHeight)
+		if int(fitWidth) <= pixelsWidth {
+			pixelsWidth = int(fitWidth)
+		} else {
+			pixelsHeight = int(float64(pixelsWidth) / pixelAspect * float64(imageHeight) / float64(imageWidth))
+		}
+		if pixelsWidth < 1 {
+			pixelsWidth = 1
+		}
+		if pixelsHeight < 1 {
+			pixelsHeight = 1
+		}
+	}
+	cellsWidth, cellsHeight := pixelsWidth, (pixelsHeight+1)/2
+
+	// Align the image.
+	offsetX, offsetY := 0, 0
+	switch i.align {
+	case AlignCenter:
+		offsetX = (width - cellsWidth) / 2
+	case AlignRight:
+		offsetX = width - cellsWidth
+	}
+	switch i.verticalAlign {
+	case AlignCenter:
+		offsetY = (height - cellsHeight) / 2
+	case AlignRight:
+		offsetY = height - cellsHeight
+	}
+	if offsetX < 0 {
+		offsetX = 0
+	}
+	if offsetY < 0 {
+		offsetY = 0
+	}
+
+	// Return the color of the pixel at the given position in the scaled image
+	// (nearest neighbor). The second return value is false if the position is
+	// outside the scaled image.
+	pixel := func(px, py int) (tcell.Color, bool) {
+		if py >= pixelsHeight {
+			return tcell.ColorDefault, false
+		}
+		imgX := bounds.Min.X + px*imageWidth/pixelsWidth
+		imgY := bounds.Min.Y + py*imageHeight/pixelsHeight
+		r, g, b, a := i.image.At(imgX, imgY).RGBA()
+		if a == 0 {
+			return tcell.ColorDefault, false
+		}
+		return tcell.NewRGBColor(int32(r>>8), int32(g>>8), int32(b>>8)), true
+	}
+
+	// Draw the pixels.
+	for row := 0; row < cellsHeight && offsetY+row < height; row++ {
+		for column := 0; column < cellsWidth && offsetX+column < width; column++ {
+			style := tcell.StyleDefault.Background(i.backgroundColor)
+			top, topVisible := pixel(column, 2*row)
+			bottom, bottomVisible := pixel(column, 2*row+1)
+			if !topVisible && !bottomVisible {
+				continue // Fully transparent, keep the box background.
+			}
+			ch := '▀'
+			if topVisible {
+				style = style.Foreground(top)
+			} else {
+				ch = '▄' // Only the bottom pixel is visible.
+				style = style.Foreground(bottom)
+			}
+			if topVisible && bottomVisible {
+				style = style.Background(bottom)
+			}
+			screen.SetContent(x+offsetX+column, y+offsetY+row, ch, nil, style)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos)
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris,!zos
+
+package tview
+
+import "github.com/derailed/tcell/v2"
+
+// newInlineScreen returns an error because screens without the alternate
+// screen buffer require a terminfo-based terminal.
+func newInlineScreen() (tcell.Screen, error) {
+	return nil, tcell.ErrNoScreen
+}
@@ -0,0 +1,72 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris zos
+
+package tview
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tcell/v2/terminfo"
+)
+
+// terminfoPadding matches padding indications ("$<5>") in terminfo strings.
+// tcell sends the strings without them.
+var terminfoPadding = regexp.MustCompile(`\$<[^>]*>`)
+
+// inlineTty is a tcell.Tty which drops the escape sequences switching to and
+// from the alternate screen buffer and clearing the screen from everything
+// tcell writes to the terminal.
+type inlineTty struct {
+	tcell.Tty
+
+	// The escape sequences to drop.
+	drop [][]byte
+}
+
+// Write writes the data to the terminal, minus the dropped sequences.
+func (t *inlineTty) Write(data []byte) (int, error) {
+	filtered := data
+	for _, sequence := range t.drop {
+		if bytes.Contains(filtered, sequence) {
+			filtered = bytes.ReplaceAll(filtered, sequence, nil)
+		}
+	}
+	if _, err := t.Tty.Write(filtered); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// newInlineScreen returns a terminfo-based screen which does not switch to the
+// alternate screen buffer and does not clear the terminal when it is
+// finalized.
+func newInlineScreen() (tcell.Screen, error) {
+	tty, err := tcell.NewDevTty()
+	if err != nil {
+		return nil, err
+	}
+	inline := &inlineTty{Tty: tty}
+	screen, err := tcell.NewTerminfoScreenFromTty(inline)
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+
+	// The screen has looked up (and, if necessary, loaded) the terminal
+	// description by now.
+	ti, err := terminfo.LookupTerminfo(os.Getenv("TERM"))
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+	for _, sequence := range []string{ti.EnterCA, ti.ExitCA, ti.Clear} {
+		if sequence = terminfoPadding.ReplaceAllString(sequence, ""); sequence != "" {
+			inline.drop = append(inline.drop, []byte(sequence))
+		}
+	}
+
+	return screen, nil
+}
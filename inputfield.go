@@ -3,13 +3,44 @@ package tview
 import (
 	"math"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/derailed/tcell/v2"
+	"github.com/rivo/uniseg"
 )
 
+// Policies which determine how multi-line text pasted into an InputField is
+// handled (see InputField.SetPasteMultilinePolicy()).
+const (
+	PasteMultilineJoin      = iota // Replace line breaks with spaces.
+	PasteMultilineFirstLine        // Keep only the first line.
+	PasteMultilineReject           // Reject the entire paste.
+)
+
+// Policies which determine what happens when the user enters text into an
+// InputField which has reached its maximum length (see
+// InputField.SetMaxLengthPolicy()).
+const (
+	MaxLengthIgnore    = iota // Silently ignore the input.
+	MaxLengthFlash            // Ignore the input and briefly flash the field.
+	MaxLengthOverwrite        // Drop the oldest characters to make room.
+)
+
+// Behaviors of the Tab key in an InputField (see InputField.SetTabBehavior()).
+const (
+	TabNavigate     = iota // Tab moves to the next field.
+	TabInsertTab           // Tab inserts a tab character.
+	TabInsertSpaces        // Tab inserts a number of spaces.
+)
+
+// The default duration for which an InputField flashes when it is full.
+const inputFieldFlashDuration = 200 * time.Millisecond
+
 // InputField is a one-line box (three lines if there is a title) where the
 // user can enter text. Use SetAcceptanceFunc() to accept or reject input,
 // SetChangedFunc() to listen for changes, and SetMaskCharacter() to hide input
@@ -27,7 +58,27 @@ import (
 //   - Delete: Delete the character after the cursor.
 //   - Ctrl-K: Delete from the cursor to the end of the line.
 //   - Ctrl-W: Delete the last word before the cursor.
-//   - Ctrl-U: Delete the entire line.
+//   - Ctrl-U: Delete the entire line (see also SetClearKey()).
+//   - Enter: Accept the text (see SetDoneFunc()).
+//   - Tab, Backtab, Down, Up: Move to the next or previous field (see
+//     SetTabBehavior() for Tab).
+//   - Escape: Abort text input.
+//   - Ctrl-R: Reveal or mask the text of a masked field (see SetRevealable()).
+//
+// Text entered with an input method (e.g. for Chinese, Japanese, or Korean) is
+// received from the terminal as regular characters once the composition is
+// finished. The terminal itself displays the text being composed at the
+// cursor position; tcell does not report it to the application. Characters
+// which consist of multiple code points (e.g. a letter followed by combining
+// marks, or emoji sequences) are treated as one unit by the cursor movement
+// and deletion keys, the maximum length, and the character counter, even if
+// their code points are received one by one.
+//
+// While the entire text is selected (see SelectAll() and
+// SetSelectAllOnFocus()), typing or pasting replaces it, Backspace and Delete
+// remove it, and Left and Right move the cursor to the beginning and the end
+// of the text. All other keys remove the selection and have their usual
+// effect.
 //
 // See https://github.com/rivo/tview/wiki/InputField for an example.
 type InputField struct {
@@ -51,6 +102,11 @@ type InputField struct {
 	// The text color of the input area.
 	fieldTextColor tcell.Color
 
+	// The style of the input area and the style used instead when the input
+	// field has focus. If these are the empty struct, the field colors are
+	// used.
+	fieldStyle, fieldStyleFocused tcell.Style
+
 	// The text color of the placeholder.
 	placeholderTextColor tcell.Color
 
@@ -62,13 +118,79 @@ type InputField struct {
 	// possible.
 	fieldWidth int
 
+	// The maximum number of characters which can be entered. A value of 0
+	// means no limit.
+	maxLength int
+
+	// The policy for input exceeding the maximum length, one of the MaxLength
+	// constants.
+	maxLengthPolicy int
+
+	// If set to true, the done and finished callbacks are invoked with KeyTab
+	// as soon as the user fills the input field up to its maximum length.
+	finishOnMaxLength bool
+
+	// An additional key which deletes the entire text. 0 if there is none.
+	clearKey tcell.Key
+
+	// The behavior of the Tab key, one of the Tab constants, and the number of
+	// spaces inserted for TabInsertSpaces.
+	tabBehavior, tabSpaces int
+
+	// The background color of the input area while it flashes.
+	flashColor tcell.Color
+
+	// How long the input area flashes. 0 disables flashing.
+	flashDuration time.Duration
+
+	// A function which causes the screen to be redrawn, called when the flash
+	// ends.
+	flashRedraw func()
+
+	// The time until which the input area flashes.
+	flashUntil time.Time
+
+	// An optional function which is called when the user tries to insert a
+	// line break.
+	newlineRejected func()
+
+	// If set to true, the number of characters entered (and the maximum, if
+	// set) is shown at the right edge of the input area.
+	showCharCount bool
+
+	// The color of the character counter.
+	charCountColor tcell.Color
+
 	// A character to mask entered text (useful for password fields). A value of 0
 	// disables masking.
 	maskCharacter rune
 
+	// If set to true, the user can reveal masked text. Whether the text is
+	// currently revealed.
+	revealable, revealed bool
+
+	// The texts of the reveal indicator while the text is masked and while it
+	// is revealed.
+	revealLabel, maskLabel string
+
+	// The x-coordinate and the width of the reveal indicator as determined
+	// during the last call to Draw(). The width is 0 if it was not drawn.
+	revealX, revealWidth int
+
 	// The cursor position as a byte index into the text string.
 	cursorPos int
 
+	// Whether the entire text is currently selected.
+	selected bool
+
+	// If set to true, the entire text is selected when the input field
+	// receives focus.
+	selectAllOnFocus bool
+
+	// The style of selected text. If this is the empty struct, the field style
+	// is reversed.
+	selectionStyle tcell.Style
+
 	// An optional autocomplete function which receives the current text of the
 	// input field and returns a slice of strings to be displayed in a drop-down
 	// selection.
@@ -79,6 +201,19 @@ type InputField struct {
 	autocompleteList      *List
 	autocompleteListMutex sync.Mutex
 
+	// An optional function which returns an inline suggestion for the current
+	// text, to be appended to it.
+	suggest func(text string) string
+
+	// The current inline suggestion (not part of the text).
+	suggestion string
+
+	// The key which accepts the inline suggestion (in addition to End).
+	suggestionKey tcell.Key
+
+	// The color of the inline suggestion.
+	suggestionColor tcell.Color
+
 	// An optional function which may reject the last character that was entered.
 	accept func(text string, ch rune) bool
 
@@ -94,6 +229,16 @@ type InputField struct {
 	// this form item.
 	finished func(tcell.Key)
 
+	// The policy for pasted text containing line breaks, one of the
+	// PasteMultiline constants.
+	pasteMultilinePolicy int
+
+	// An optional function which is called when pasted text was rejected.
+	pasteRejected func(text string)
+
+	// An optional function which transforms pasted text before it is inserted.
+	pasteFunc func(raw string) string
+
 	fieldX int // The x-coordinate of the input field as determined during the last call to Draw().
 	offset int // The number of bytes of the text string skipped ahead while drawing.
 }
@@ -106,19 +251,55 @@ func NewInputField() *InputField {
 		fieldBackgroundColor: Styles.ContrastBackgroundColor,
 		fieldTextColor:       Styles.PrimaryTextColor,
 		placeholderTextColor: Styles.ContrastSecondaryTextColor,
+		charCountColor:       Styles.ContrastSecondaryTextColor,
+		suggestionKey:        tcell.KeyRight,
+		suggestionColor:      Styles.ContrastSecondaryTextColor,
+		flashColor:           tcell.ColorRed,
+		flashDuration:        inputFieldFlashDuration,
+		revealLabel:          "show",
+		maskLabel:            "hide",
 	}
 }
 
-// SetText sets the current text of the input field.
+// SetText sets the current text of the input field. The text is not checked
+// against the maximum length or the acceptance function. Use TrySetText() for
+// that.
 func (i *InputField) SetText(text string) *InputField {
 	i.text = text
 	i.cursorPos = len(text)
+	i.selected = false
+	i.updateSuggestion()
 	if i.changed != nil {
 		i.changed(text)
 	}
 	return i
 }
 
+// TrySetText works like SetText() but applies the same rules as for typed
+// input: If the text is longer than the maximum length (see SetMaxLength()),
+// it is truncated at the end. The resulting text is then checked with the
+// acceptance function (see SetAcceptanceFunc()), receiving the text's last
+// character. If it is rejected, the input field remains unchanged and false is
+// returned.
+func (i *InputField) TrySetText(text string) bool {
+	if i.maxLength > 0 {
+		for text != "" && uniseg.GraphemeClusterCount(text) > i.maxLength {
+			iterateStringReverse(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				text = text[:textPos]
+				return true
+			})
+		}
+	}
+	if i.accept != nil && text != "" {
+		lastChar, _ := utf8.DecodeLastRuneInString(text)
+		if !i.accept(text, lastChar) {
+			return false
+		}
+	}
+	i.SetText(text)
+	return true
+}
+
 // GetText returns the current text of the input field.
 func (i *InputField) GetText() string {
 	return i.text
@@ -166,6 +347,57 @@ func (i *InputField) SetFieldTextColor(color tcell.Color) *InputField {
 	return i
 }
 
+// SetFieldStyle sets the style of the input area, i.e. its background color,
+// text color, and text attributes (e.g. bold or underline). If set, it takes
+// precedence over the colors set with SetFieldBackgroundColor() and
+// SetFieldTextColor(). To reset the style to these colors, make the following
+// call:
+//
+//	inputField.SetFieldStyle(tcell.Style{})
+func (i *InputField) SetFieldStyle(style tcell.Style) *InputField {
+	i.fieldStyle = style
+	return i
+}
+
+// SetFieldStyleFocused sets the style of the input area while the input field
+// has focus. If not set (or set to tcell.Style{}), the input area looks the
+// same with and without focus.
+func (i *InputField) SetFieldStyleFocused(style tcell.Style) *InputField {
+	i.fieldStyleFocused = style
+	return i
+}
+
+// SetSelectAllOnFocus sets whether the entire text is selected when the input
+// field receives focus, e.g. when the user tabs into it in a form, so that
+// typing replaces the text. Clicking into the field with the mouse places the
+// cursor at the click position instead. If set to false (the default), the
+// cursor remains where it was, which is the end of the text after SetText().
+func (i *InputField) SetSelectAllOnFocus(selectAll bool) *InputField {
+	i.selectAllOnFocus = selectAll
+	return i
+}
+
+// SelectAll selects the entire text and moves the cursor to its end. Nothing
+// is selected if the text is empty. The selection is removed when the input
+// field loses focus.
+func (i *InputField) SelectAll() *InputField {
+	i.selected = i.text != ""
+	i.cursorPos = len(i.text)
+	return i
+}
+
+// HasSelection returns whether the entire text is currently selected.
+func (i *InputField) HasSelection() bool {
+	return i.selected
+}
+
+// SetSelectionStyle sets the style of selected text. If not set (or set to
+// tcell.Style{}), the style of the input area is reversed.
+func (i *InputField) SetSelectionStyle(style tcell.Style) *InputField {
+	i.selectionStyle = style
+	return i
+}
+
 // SetPlaceholderTextColor sets the text color of placeholder text.
 func (i *InputField) SetPlaceholderTextColor(color tcell.Color) *InputField {
 	i.placeholderTextColor = color
@@ -194,6 +426,173 @@ func (i *InputField) GetFieldWidth() int {
 	return i.fieldWidth
 }
 
+// SetMaxLength sets the maximum number of characters which can be entered or
+// pasted into the input field. Characters are grapheme clusters, i.e. a
+// character followed by combining marks counts as one, so combining marks can
+// still be added to the last character of a full field. A value of 0 (the
+// default) means no limit. Text set with SetText() is not truncated. See
+// SetMaxLengthPolicy() for what happens when the field is full.
+func (i *InputField) SetMaxLength(maxLength int) *InputField {
+	i.maxLength = maxLength
+	return i
+}
+
+// SetShowCharCount sets whether or not a character counter is shown at the
+// right edge of the input area. It shows the number of characters entered
+// or, if a maximum length was set with SetMaxLength(), the number and the
+// maximum, e.g. "42/72". The counter is not part of the editable area.
+func (i *InputField) SetShowCharCount(show bool) *InputField {
+	i.showCharCount = show
+	return i
+}
+
+// SetCharCountColor sets the color of the character counter.
+func (i *InputField) SetCharCountColor(color tcell.Color) *InputField {
+	i.charCountColor = color
+	return i
+}
+
+// SetMaxLengthPolicy sets what happens when the user types or pastes text
+// into the input field which would exceed the maximum length set with
+// SetMaxLength(). It is one of the following:
+//
+//   - MaxLengthIgnore: The input is ignored (the default).
+//   - MaxLengthFlash: The input is ignored and the input area briefly flashes
+//     in the color set with SetFlashColor() (see also SetFlash()).
+//   - MaxLengthOverwrite: The oldest characters are removed to make room for
+//     the new ones, as in a fixed-width buffer. This is useful for fixed-length
+//     codes.
+func (i *InputField) SetMaxLengthPolicy(policy int) *InputField {
+	i.maxLengthPolicy = policy
+	return i
+}
+
+// SetFlashColor sets the background color of the input area when it flashes
+// (see SetMaxLengthPolicy()).
+func (i *InputField) SetFlashColor(color tcell.Color) *InputField {
+	i.flashColor = color
+	return i
+}
+
+// SetFlash sets how long the input area flashes (200ms by default) when input
+// is rejected (see SetMaxLengthPolicy() and SetNewlineRejectedFunc()). A
+// duration of 0 disables flashing.
+//
+// The flash is shown with the next redraw after the key event. To remove it
+// after the duration, the provided "redraw" function is called from a
+// separate goroutine. It typically calls Application.Draw(). If it is nil, the
+// flash is removed with the first redraw after the duration.
+func (i *InputField) SetFlash(duration time.Duration, redraw func()) *InputField {
+	i.flashDuration = duration
+	i.flashRedraw = redraw
+	return i
+}
+
+// flash makes the input area flash (if enabled).
+func (i *InputField) flash() {
+	if i.flashDuration <= 0 {
+		return
+	}
+	i.flashUntil = time.Now().Add(i.flashDuration)
+	if i.flashRedraw != nil {
+		time.AfterFunc(i.flashDuration, i.flashRedraw)
+	}
+}
+
+// SetFinishOnMaxLength sets whether the done function (see SetDoneFunc()) is
+// invoked with tcell.KeyTab as soon as the user types or pastes text which
+// fills the input field up to the maximum length set with SetMaxLength(). In a
+// form, this moves the focus to the next item. This is useful for fixed-length
+// input such as PIN codes. It has no effect if no maximum length was set.
+func (i *InputField) SetFinishOnMaxLength(finish bool) *InputField {
+	i.finishOnMaxLength = finish
+	return i
+}
+
+// SetClearKey sets an additional key which deletes the entire text and moves
+// the cursor to the beginning, like Ctrl-U, e.g. tcell.KeyCtrlL or
+// tcell.KeyCtrlC. (Note that by default, Ctrl-C stops the application. See
+// Application.SetInputCapture() to change that.) The changed function is
+// called if the field was not empty. Set to 0 (the default) for no additional
+// key.
+func (i *InputField) SetClearKey(key tcell.Key) *InputField {
+	i.clearKey = key
+	return i
+}
+
+// SetTabBehavior sets what happens when the user presses the Tab key, one of
+// the following:
+//
+//   - TabNavigate: The done function is called with KeyTab, e.g. to move to
+//     the next form item (the default).
+//   - TabInsertTab: A tab character is inserted. It is displayed as a single
+//     space.
+//   - TabInsertSpaces: The given number of spaces is inserted.
+//
+// When Tab inserts text, the done function is not called for it. The
+// inserted text is subject to the acceptance function and the maximum
+// length. Backtab and the other navigation keys are not affected. While the
+// autocomplete drop-down is shown, Tab still selects the current entry.
+func (i *InputField) SetTabBehavior(behavior, spaces int) *InputField {
+	i.tabBehavior = behavior
+	i.tabSpaces = spaces
+	return i
+}
+
+// finishIfFull invokes the done and finished callbacks with KeyTab if text
+// was just entered which filled the input field up to its maximum length and
+// SetFinishOnMaxLength() was enabled.
+func (i *InputField) finishIfFull() {
+	if !i.finishOnMaxLength || i.maxLength <= 0 || uniseg.GraphemeClusterCount(i.text) < i.maxLength {
+		return
+	}
+	if i.done != nil {
+		i.done(tcell.KeyTab)
+	}
+	if i.finished != nil {
+		i.finished(tcell.KeyTab)
+	}
+}
+
+// fitMaxLength applies the maximum length policy to the given new text with
+// the cursor (a byte position) placed after the inserted text. It returns the
+// resulting text and cursor position and whether the text is acceptable.
+func (i *InputField) fitMaxLength(text string, cursor int) (string, int, bool) {
+	if i.maxLength <= 0 || uniseg.GraphemeClusterCount(text) <= i.maxLength {
+		return text, cursor, true
+	}
+	switch i.maxLengthPolicy {
+	case MaxLengthOverwrite:
+		// Drop entire characters (grapheme clusters) so that no combining
+		// characters are left dangling.
+		for text != "" && uniseg.GraphemeClusterCount(text) > i.maxLength {
+			if cursor > 0 {
+				var size int
+				iterateString(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+					size = textWidth
+					return true
+				})
+				if size > cursor {
+					size = cursor
+				}
+				text = text[size:]
+				cursor -= size
+			} else {
+				var size int
+				iterateStringReverse(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+					size = textWidth
+					return true
+				})
+				text = text[:len(text)-size]
+			}
+		}
+		return text, cursor, true
+	case MaxLengthFlash:
+		i.flash()
+	}
+	return text, cursor, false
+}
+
 // SetMaskCharacter sets a character that masks user input on a screen. A value
 // of 0 disables masking.
 func (i *InputField) SetMaskCharacter(mask rune) *InputField {
@@ -201,6 +600,45 @@ func (i *InputField) SetMaskCharacter(mask rune) *InputField {
 	return i
 }
 
+// SetRevealable sets whether the user can temporarily reveal the text of a
+// masked field (see SetMaskCharacter()), a common affordance for password
+// fields. If set to true, an indicator is shown at the right end of the input
+// area which toggles between the masked and the plain text when clicked.
+// Ctrl-R does the same. The text is masked again when the field loses focus.
+// GetText() always returns the plain text.
+func (i *InputField) SetRevealable(revealable bool) *InputField {
+	i.revealable = revealable
+	if !revealable {
+		i.revealed = false
+	}
+	return i
+}
+
+// SetRevealLabels sets the texts of the reveal indicator (see SetRevealable()),
+// shown while the text is masked and while it is revealed, respectively. The
+// defaults are "show" and "hide".
+func (i *InputField) SetRevealLabels(reveal, mask string) *InputField {
+	i.revealLabel, i.maskLabel = reveal, mask
+	return i
+}
+
+// SetRevealed reveals the text of a revealable masked field (true) or masks it
+// again (false). See SetRevealable().
+func (i *InputField) SetRevealed(revealed bool) *InputField {
+	i.revealed = revealed && i.revealable
+	return i
+}
+
+// IsRevealed returns whether the text of a masked field is currently revealed.
+func (i *InputField) IsRevealed() bool {
+	return i.revealed
+}
+
+// masked returns whether the text is currently displayed masked.
+func (i *InputField) masked() bool {
+	return i.maskCharacter > 0 && !i.revealed
+}
+
 // SetAutocompleteFunc sets an autocomplete callback function which may return
 // strings to be selected from a drop-down based on the current text of the
 // input field. The drop-down appears only if len(entries) > 0. The callback is
@@ -267,6 +705,48 @@ func (i *InputField) Autocomplete() *InputField {
 	return i
 }
 
+// SetSuggestFunc sets a function which returns an inline suggestion for the
+// current text, as in some shells. The function receives the current text and
+// returns the text to be appended to it (not the complete text), or an empty
+// string if there is no suggestion. It is called whenever the text changes.
+//
+// The suggestion is displayed after the entered text in the color set with
+// SetSuggestionColor() while the cursor is at the end of the text. It is not
+// part of the text returned by GetText() until the user accepts it with the
+// key set with SetSuggestionKey() (the right arrow key by default) or with
+// the End key. Like pasted text, the accepted text is subject to the maximum
+// length and the acceptance function (see SetMaxLength() and
+// SetAcceptanceFunc()). Masked input fields don't show suggestions. Provide
+// nil to remove suggestions.
+func (i *InputField) SetSuggestFunc(suggest func(currentText string) string) *InputField {
+	i.suggest = suggest
+	i.updateSuggestion()
+	return i
+}
+
+// SetSuggestionKey sets the key which accepts the inline suggestion (see
+// SetSuggestFunc()), in addition to the End key. The default is
+// tcell.KeyRight.
+func (i *InputField) SetSuggestionKey(key tcell.Key) *InputField {
+	i.suggestionKey = key
+	return i
+}
+
+// SetSuggestionColor sets the color of the inline suggestion (see
+// SetSuggestFunc()).
+func (i *InputField) SetSuggestionColor(color tcell.Color) *InputField {
+	i.suggestionColor = color
+	return i
+}
+
+// updateSuggestion retrieves a new inline suggestion for the current text.
+func (i *InputField) updateSuggestion() {
+	i.suggestion = ""
+	if i.suggest != nil && i.maskCharacter == 0 {
+		i.suggestion = i.suggest(i.text)
+	}
+}
+
 // SetAcceptanceFunc sets a handler which may reject the last character that was
 // entered (by returning false).
 //
@@ -288,15 +768,149 @@ func (i *InputField) SetChangedFunc(handler func(text string)) *InputField {
 // text. The callback function is provided with the key that was pressed, which
 // is one of the following:
 //
-//   - KeyEnter: Done entering text.
+//   - KeyEnter: Accept the entered text (e.g. submit a search).
 //   - KeyEscape: Abort text input.
 //   - KeyTab: Move to the next field.
 //   - KeyBacktab: Move to the previous field.
+//   - KeyDown: Move to the next field.
+//   - KeyUp: Move to the previous field.
+//
+// Enter is always delivered as KeyEnter so it can be told apart from the
+// navigation keys. While the autocomplete drop-down is shown, these keys
+// operate the drop-down instead and the handler is not called, except for
+// Escape which closes the drop-down first and is delivered on the next press.
+// KeyTab is also delivered when the field fills up and SetFinishOnMaxLength()
+// was enabled. Shift-Enter, Alt-Enter, and Ctrl-J are delivered as KeyEnter
+// unless a handler was set with SetNewlineRejectedFunc().
 func (i *InputField) SetDoneFunc(handler func(key tcell.Key)) *InputField {
 	i.done = handler
 	return i
 }
 
+// SetNewlineRejectedFunc sets a handler which is called when the user tries to
+// insert a line break into this single-line field, i.e. presses Shift-Enter,
+// Alt-Enter, or Ctrl-J. This way, applications can point out that Enter
+// submits the text, e.g. in a status line. While a handler is set, the input
+// area also flashes briefly (see SetFlash() and SetFlashColor()) and these keys
+// do not submit the text. By default (nil), they are treated like Enter.
+func (i *InputField) SetNewlineRejectedFunc(handler func()) *InputField {
+	i.newlineRejected = handler
+	return i
+}
+
+// SetPasteMultilinePolicy sets how text containing line breaks is handled when
+// it is pasted into this input field (see Paste()). It is one of the following:
+//
+//   - PasteMultilineJoin: Line breaks are replaced with spaces (the default).
+//   - PasteMultilineFirstLine: Only the text up to the first line break is
+//     inserted.
+//   - PasteMultilineReject: The paste is rejected entirely.
+func (i *InputField) SetPasteMultilinePolicy(policy int) *InputField {
+	i.pasteMultilinePolicy = policy
+	return i
+}
+
+// SetPasteRejectedFunc sets a handler which is called when pasted text is
+// rejected, either because of the multi-line policy (see
+// SetPasteMultilinePolicy()) or because the acceptance function (see
+// SetAcceptanceFunc()) refused it. The handler receives the pasted text.
+func (i *InputField) SetPasteRejectedFunc(handler func(text string)) *InputField {
+	i.pasteRejected = handler
+	return i
+}
+
+// SetPasteFunc sets a function which transforms pasted text before it is
+// inserted (see Paste()), e.g. to normalize file paths which were dragged into
+// the terminal. The function is applied before line breaks are handled. See
+// UnquotePath() for a function suited for file paths. Provide nil to insert
+// pasted text unchanged (the default).
+func (i *InputField) SetPasteFunc(handler func(raw string) string) *InputField {
+	i.pasteFunc = handler
+	return i
+}
+
+// UnquotePath normalizes a file path as it is typically pasted by terminals
+// when a file is dragged into them: Surrounding whitespace is removed, and the
+// path is either unquoted if it is enclosed in single or double quotes, or
+// backslash escapes (e.g. "\ " for a space) are removed. As backslashes are
+// treated as escape characters, this is not suitable for Windows paths. It can
+// be used with InputField.SetPasteFunc().
+func UnquotePath(raw string) string {
+	path := strings.TrimSpace(raw)
+	if len(path) >= 2 && (path[0] == '"' || path[0] == '\'') && path[len(path)-1] == path[0] {
+		return path[1 : len(path)-1]
+	}
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+	var (
+		b       strings.Builder
+		escaped bool
+	)
+	for _, r := range path {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Paste inserts the given text at the current cursor position as if the user
+// had pasted it from the clipboard. The text is first transformed by the paste
+// function (see SetPasteFunc()), if one was set. Line breaks are handled
+// according to the multi-line policy (see SetPasteMultilinePolicy()). If the
+// entire text is selected, it is replaced. The resulting text is checked with
+// the acceptance function as a whole. Returns whether or not the text was
+// inserted.
+func (i *InputField) Paste(text string) bool {
+	pasted := text
+	if i.pasteFunc != nil {
+		pasted = i.pasteFunc(pasted)
+	}
+	if strings.ContainsAny(pasted, "\r\n") {
+		switch i.pasteMultilinePolicy {
+		case PasteMultilineFirstLine:
+			pasted = pasted[:strings.IndexAny(pasted, "\r\n")]
+		case PasteMultilineReject:
+			if i.pasteRejected != nil {
+				i.pasteRejected(text)
+			}
+			return false
+		default:
+			pasted = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(pasted)
+		}
+	}
+	if pasted == "" {
+		return true
+	}
+
+	before, after := i.text[:i.cursorPos], i.text[i.cursorPos:]
+	if i.selected {
+		before, after = "", ""
+	}
+	newText, cursorPos, ok := i.fitMaxLength(before+pasted+after, len(before)+len(pasted))
+	lastChar, _ := utf8.DecodeLastRuneInString(pasted)
+	if !ok || i.accept != nil && !i.accept(newText, lastChar) {
+		if i.pasteRejected != nil {
+			i.pasteRejected(text)
+		}
+		return false
+	}
+	i.text = newText
+	i.cursorPos = cursorPos
+	i.selected = false
+	i.Autocomplete()
+	i.updateSuggestion()
+	if i.changed != nil {
+		i.changed(i.text)
+	}
+	i.finishIfFull()
+	return true
+}
+
 // SetFinishedFunc sets a callback invoked when the user leaves this form item.
 func (i *InputField) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
 	i.finished = handler
@@ -336,10 +950,53 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	if rightLimit-x < fieldWidth {
 		fieldWidth = rightLimit - x
 	}
-	fieldStyle := tcell.StyleDefault.Background(i.fieldBackgroundColor)
+	fieldStyle := tcell.StyleDefault.Background(i.fieldBackgroundColor).Foreground(i.fieldTextColor)
+	if i.HasFocus() && i.fieldStyleFocused != (tcell.Style{}) {
+		fieldStyle = i.fieldStyleFocused
+	} else if i.fieldStyle != (tcell.Style{}) {
+		fieldStyle = i.fieldStyle
+	}
+	if time.Now().Before(i.flashUntil) {
+		fieldStyle = fieldStyle.Background(i.flashColor)
+	}
 	for index := 0; index < fieldWidth; index++ {
 		screen.SetContent(x+index, y, ' ', nil, fieldStyle)
 	}
+	textStyle := fieldStyle
+	if i.selected {
+		textStyle = fieldStyle.Reverse(true)
+		if i.selectionStyle != (tcell.Style{}) {
+			textStyle = i.selectionStyle
+		}
+	}
+
+	// Draw the character counter.
+	if i.showCharCount {
+		counter := strconv.Itoa(uniseg.GraphemeClusterCount(i.text))
+		counterWidth := len(counter)
+		if i.maxLength > 0 {
+			counter += "/" + strconv.Itoa(i.maxLength)
+			counterWidth = 2*len(strconv.Itoa(i.maxLength)) + 1
+		}
+		if counterWidth+1 < fieldWidth {
+			Print(screen, counter, x+fieldWidth-counterWidth, y, counterWidth, AlignRight, i.charCountColor)
+			fieldWidth -= counterWidth + 1
+		}
+	}
+
+	// Draw the reveal indicator.
+	i.revealWidth = 0
+	if i.revealable && i.maskCharacter > 0 {
+		label := i.revealLabel
+		if i.revealed {
+			label = i.maskLabel
+		}
+		if labelWidth := TaggedStringWidth(label); labelWidth > 0 && labelWidth+1 < fieldWidth {
+			i.revealX, i.revealWidth = x+fieldWidth-labelWidth, labelWidth
+			Print(screen, label, i.revealX, y, labelWidth, AlignLeft, i.placeholderTextColor)
+			fieldWidth -= labelWidth + 1
+		}
+	}
 
 	// Text.
 	var cursorScreenPos int
@@ -349,43 +1006,60 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		Print(screen, Escape(i.placeholder), x, y, fieldWidth, AlignLeft, i.placeholderTextColor)
 		i.offset = 0
 	} else {
-		// Draw entered text.
-		if i.maskCharacter > 0 {
-			text = strings.Repeat(string(i.maskCharacter), utf8.RuneCountInString(i.text))
+		// Draw entered text. Positions are byte positions in the displayed text
+		// which differs from the actual text if it is masked.
+		if i.cursorPos < 0 {
+			i.cursorPos = 0
+		} else if i.cursorPos > len(i.text) {
+			i.cursorPos = len(i.text)
+		}
+		cursorPos, offset := i.cursorPos, i.offset
+		var (
+			clusters []int // The start positions of all characters in a masked text.
+			mask     string
+		)
+		if i.masked() {
+			// Each character (grapheme cluster) is replaced with one mask.
+			iterateString(i.text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				clusters = append(clusters, textPos)
+				return false
+			})
+			mask = string(i.maskCharacter)
+			text = strings.Repeat(mask, len(clusters))
+			cursorPos = sort.SearchInts(clusters, cursorPos) * len(mask)
+			offset = sort.SearchInts(clusters, offset) * len(mask)
+		} else {
+			// Tabs are displayed as single spaces (which keeps byte positions).
+			text = strings.ReplaceAll(text, "\t", " ")
 		}
 		if fieldWidth >= stringWidth(text) {
 			// We have enough space for the full text.
-			Print(screen, Escape(text), x, y, fieldWidth, AlignLeft, i.fieldTextColor)
-			i.offset = 0
+			printWithStyle(screen, Escape(text), x, y, 0, fieldWidth, AlignLeft, textStyle, false)
+			offset = 0
 			iterateString(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
-				if textPos >= i.cursorPos {
+				if textPos >= cursorPos {
 					return true
 				}
 				cursorScreenPos += screenWidth
 				return false
 			})
 		} else {
-			// The text doesn't fit. Where is the cursor?
-			if i.cursorPos < 0 {
-				i.cursorPos = 0
-			} else if i.cursorPos > len(text) {
-				i.cursorPos = len(text)
-			}
-			// Shift the text so the cursor is inside the field.
+			// The text doesn't fit. Shift the text so the cursor is inside the
+			// field.
 			var shiftLeft int
-			if i.offset > i.cursorPos {
-				i.offset = i.cursorPos
-			} else if subWidth := stringWidth(text[i.offset:i.cursorPos]); subWidth > fieldWidth-1 {
+			if offset > cursorPos {
+				offset = cursorPos
+			} else if subWidth := stringWidth(text[offset:cursorPos]); subWidth > fieldWidth-1 {
 				shiftLeft = subWidth - fieldWidth + 1
 			}
-			currentOffset := i.offset
+			currentOffset := offset
 			iterateString(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
 				if textPos >= currentOffset {
 					if shiftLeft > 0 {
-						i.offset = textPos + textWidth
+						offset = textPos + textWidth
 						shiftLeft -= screenWidth
 					} else {
-						if textPos+textWidth > i.cursorPos {
+						if textPos+textWidth > cursorPos {
 							return true
 						}
 						cursorScreenPos += screenWidth
@@ -393,7 +1067,22 @@ func (i *InputField) Draw(screen tcell.Screen) {
 				}
 				return false
 			})
-			Print(screen, Escape(text[i.offset:]), x, y, fieldWidth, AlignLeft, i.fieldTextColor)
+			printWithStyle(screen, Escape(text[offset:]), x, y, 0, fieldWidth, AlignLeft, textStyle, false)
+		}
+
+		// Store the offset as a position in the actual text.
+		if clusters != nil {
+			if n := offset / len(mask); n < len(clusters) {
+				offset = clusters[n]
+			} else {
+				offset = len(i.text)
+			}
+		}
+		i.offset = offset
+
+		// Draw the inline suggestion after the text.
+		if i.suggestion != "" && i.maskCharacter == 0 && i.cursorPos == len(i.text) && cursorScreenPos < fieldWidth {
+			Print(screen, Escape(i.suggestion), x+cursorScreenPos, y, fieldWidth-cursorScreenPos, AlignLeft, i.suggestionColor)
 		}
 	}
 
@@ -413,18 +1102,8 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		}
 
 		// We prefer to drop down but if there is no space, maybe drop up?
-		lx := x
-		ly := y + 1
-		_, sheight := screen.Size()
-		if ly+lheight >= sheight && ly-2 > lheight-ly {
-			ly = y - lheight
-			if ly < 0 {
-				ly = 0
-			}
-		}
-		if ly+lheight >= sheight {
-			lheight = sheight - ly
-		}
+		swidth, sheight := screen.Size()
+		lx, ly, lwidth, lheight := PopupRect(x, y, fieldWidth, 1, lwidth, lheight, PopupBelow, swidth, sheight)
 		i.autocompleteList.SetRect(lx, ly, lwidth, lheight)
 		i.autocompleteList.Draw(screen)
 	}
@@ -435,17 +1114,39 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	}
 }
 
+// Focus is called when this primitive receives focus. The entire text is
+// selected if requested with SetSelectAllOnFocus().
+func (i *InputField) Focus(delegate func(p Primitive)) {
+	if i.selectAllOnFocus && !i.HasFocus() {
+		i.SelectAll()
+	}
+	i.Box.Focus(delegate)
+}
+
+// Blur is called when this primitive loses focus. Revealed text is masked
+// again and the selection is removed.
+func (i *InputField) Blur() {
+	i.revealed = false
+	i.selected = false
+	i.Box.Blur()
+}
+
 // InputHandler returns the handler for this primitive.
 func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return i.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		// Trigger changed events.
 		currentText := i.text
+		var added bool
 		defer func() {
 			if i.text != currentText {
 				i.Autocomplete()
+				i.updateSuggestion()
 				if i.changed != nil {
 					i.changed(i.text)
 				}
+				if added {
+					i.finishIfFull()
+				}
 			}
 		}()
 
@@ -474,12 +1175,13 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 		// Add character function. Returns whether or not the rune character is
 		// accepted.
 		add := func(r rune) bool {
-			newText := i.text[:i.cursorPos] + string(r) + i.text[i.cursorPos:]
-			if i.accept != nil && !i.accept(newText, r) {
+			newText, cursorPos, ok := i.fitMaxLength(i.text[:i.cursorPos]+string(r)+i.text[i.cursorPos:], i.cursorPos+len(string(r)))
+			if !ok || i.accept != nil && !i.accept(newText, r) {
 				return false
 			}
 			i.text = newText
-			i.cursorPos += len(string(r))
+			i.cursorPos = cursorPos
+			added = true
 			return true
 		}
 
@@ -511,6 +1213,63 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 		// Process key event.
 		i.autocompleteListMutex.Lock()
 		defer i.autocompleteListMutex.Unlock()
+
+		// Accept the inline suggestion.
+		if key := event.Key(); i.suggestion != "" && i.cursorPos == len(i.text) && i.autocompleteList == nil &&
+			(key == i.suggestionKey || key == tcell.KeyEnd) && event.Modifiers()&tcell.ModAlt == 0 {
+			newText, cursorPos, ok := i.fitMaxLength(i.text+i.suggestion, len(i.text)+len(i.suggestion))
+			lastChar, _ := utf8.DecodeLastRuneInString(i.suggestion)
+			if ok && (i.accept == nil || i.accept(newText, lastChar)) {
+				i.text = newText
+				i.cursorPos = cursorPos
+				i.selected = false
+				added = true
+			}
+			return
+		}
+
+		// Delete the entire text.
+		if i.clearKey != 0 && event.Key() == i.clearKey {
+			i.text = ""
+			i.cursorPos = 0
+			i.offset = 0
+			return
+		}
+
+		// Reject attempts to insert line breaks.
+		if key := event.Key(); i.newlineRejected != nil && i.autocompleteList == nil &&
+			(key == tcell.KeyEnter && event.Modifiers()&(tcell.ModShift|tcell.ModAlt) != 0 || key == tcell.KeyCtrlJ) {
+			i.flash()
+			i.newlineRejected()
+			return
+		}
+
+		// Replace or remove the selected text.
+		if i.selected {
+			i.selected = false
+			switch key := event.Key(); key {
+			case tcell.KeyRune:
+				if event.Modifiers()&tcell.ModAlt > 0 {
+					break
+				}
+				text, cursorPos := i.text, i.cursorPos
+				i.text, i.cursorPos, i.offset = "", 0, 0
+				if !add(event.Rune()) {
+					i.text, i.cursorPos, i.selected = text, cursorPos, true
+				}
+				return
+			case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyDelete, tcell.KeyCtrlD:
+				i.text, i.cursorPos, i.offset = "", 0, 0
+				return
+			case tcell.KeyLeft:
+				home()
+				return
+			case tcell.KeyRight:
+				end()
+				return
+			}
+		}
+
 		switch key := event.Key(); key {
 		case tcell.KeyRune: // Regular character.
 			if event.Modifiers()&tcell.ModAlt > 0 {
@@ -535,9 +1294,14 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 					return
 				}
 			}
+		case tcell.KeyCtrlR: // Reveal or mask the text.
+			if i.revealable && i.maskCharacter > 0 {
+				i.revealed = !i.revealed
+			}
 		case tcell.KeyCtrlU: // Delete all.
 			i.text = ""
 			i.cursorPos = 0
+			i.offset = 0
 		case tcell.KeyCtrlK: // Delete until the end of the line.
 			i.text = i.text[:i.cursorPos]
 		case tcell.KeyCtrlW: // Delete last word.
@@ -579,12 +1343,12 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 			home()
 		case tcell.KeyEnd, tcell.KeyCtrlE:
 			end()
-		case tcell.KeyEnter:
+		case tcell.KeyEnter, tcell.KeyCtrlJ:
 			if i.autocompleteList != nil {
 				autocompleteSelect(0)
 				i.autocompleteList = nil
 			} else {
-				finish(key)
+				finish(tcell.KeyEnter)
 			}
 		case tcell.KeyEscape:
 			if i.autocompleteList != nil {
@@ -595,6 +1359,14 @@ func (i *InputField) InputHandler() func(event *tcell.EventKey, setFocus func(p
 		case tcell.KeyTab:
 			if i.autocompleteList != nil {
 				autocompleteSelect(0)
+			} else if i.tabBehavior == TabInsertTab {
+				add('\t')
+			} else if i.tabBehavior == TabInsertSpaces {
+				for n := 0; n < i.tabSpaces; n++ {
+					if !add(' ') {
+						break
+					}
+				}
 			} else {
 				finish(key)
 			}
@@ -624,10 +1396,23 @@ func (i *InputField) MouseHandler() func(action MouseAction, event *tcell.EventM
 		}
 
 		// Process mouse event.
+		if action == MouseLeftClick && y == rectY && i.revealWidth > 0 && x >= i.revealX && x < i.revealX+i.revealWidth {
+			// Toggle the reveal state.
+			i.revealed = !i.revealed
+			setFocus(i)
+			return true, nil
+		}
 		if action == MouseLeftClick && y == rectY {
 			// Determine where to place the cursor.
 			if x >= i.fieldX {
+				var index int
+				maskWidth := stringWidth(string(i.maskCharacter))
 				if !iterateString(i.text[i.offset:], func(main rune, comb []rune, textPos int, textWidth int, screenPos int, screenWidth int) bool {
+					if i.masked() {
+						// Each character is displayed as one mask character.
+						screenPos, screenWidth = index*maskWidth, maskWidth
+						index++
+					}
 					if x-i.fieldX < screenPos+screenWidth {
 						i.cursorPos = textPos + i.offset
 						return true
@@ -637,7 +1422,9 @@ func (i *InputField) MouseHandler() func(action MouseAction, event *tcell.EventM
 					i.cursorPos = len(i.text)
 				}
 			}
+			cursorPos := i.cursorPos
 			setFocus(i)
+			i.cursorPos, i.selected = cursorPos, false // Clicks don't select.
 			consumed = true
 		}
 
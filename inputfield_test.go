@@ -0,0 +1,244 @@
+package tview
+
+import (
+	"testing"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// Text containing multi-rune characters (grapheme clusters): "e" followed by a
+// combining acute accent, a thumbs-up emoji with a skin tone modifier, and
+// a woman technologist (a ZWJ sequence).
+const (
+	inputFieldTestAccent = "e\u0301"
+	inputFieldTestThumbs = "\U0001F44D\U0001F3FD"
+	inputFieldTestCoder  = "\U0001F469\u200d\U0001F4BB"
+)
+
+func TestInputFieldFitMaxLength(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     int
+		maxLength  int
+		text       string
+		cursor     int
+		wantText   string
+		wantCursor int
+		wantOK     bool
+	}{
+		{
+			name:       "fits",
+			maxLength:  3,
+			text:       "a" + inputFieldTestAccent + inputFieldTestThumbs,
+			cursor:     1,
+			wantText:   "a" + inputFieldTestAccent + inputFieldTestThumbs,
+			wantCursor: 1,
+			wantOK:     true,
+		},
+		{
+			name:       "too long ignored",
+			maxLength:  2,
+			text:       "a" + inputFieldTestAccent + "b",
+			cursor:     4,
+			wantText:   "a" + inputFieldTestAccent + "b",
+			wantCursor: 4,
+			wantOK:     false,
+		},
+		{
+			name:       "overwrite drops combining mark with its base",
+			policy:     MaxLengthOverwrite,
+			maxLength:  2,
+			text:       inputFieldTestAccent + "ab",
+			cursor:     len(inputFieldTestAccent) + 2,
+			wantText:   "ab",
+			wantCursor: 2,
+			wantOK:     true,
+		},
+		{
+			name:       "overwrite drops entire emoji",
+			policy:     MaxLengthOverwrite,
+			maxLength:  2,
+			text:       inputFieldTestCoder + inputFieldTestThumbs + "x",
+			cursor:     len(inputFieldTestCoder + inputFieldTestThumbs + "x"),
+			wantText:   inputFieldTestThumbs + "x",
+			wantCursor: len(inputFieldTestThumbs + "x"),
+			wantOK:     true,
+		},
+		{
+			name:       "overwrite at start drops from the end",
+			policy:     MaxLengthOverwrite,
+			maxLength:  2,
+			text:       "x" + inputFieldTestAccent + inputFieldTestThumbs,
+			cursor:     0,
+			wantText:   "x" + inputFieldTestAccent,
+			wantCursor: 0,
+			wantOK:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := NewInputField().SetMaxLength(test.maxLength).SetMaxLengthPolicy(test.policy)
+			text, cursor, ok := i.fitMaxLength(test.text, test.cursor)
+			if text != test.wantText || cursor != test.wantCursor || ok != test.wantOK {
+				t.Errorf("fitMaxLength(%q, %d) = (%q, %d, %t), want (%q, %d, %t)", test.text, test.cursor, text, cursor, ok, test.wantText, test.wantCursor, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestInputFieldDeleteClusters(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		cursor     int
+		key        tcell.Key
+		wantText   string
+		wantCursor int
+	}{
+		{
+			name:       "backspace after combining mark",
+			text:       "a" + inputFieldTestAccent + "b",
+			cursor:     1 + len(inputFieldTestAccent),
+			key:        tcell.KeyBackspace2,
+			wantText:   "ab",
+			wantCursor: 1,
+		},
+		{
+			name:       "backspace after emoji with modifier",
+			text:       "a" + inputFieldTestThumbs,
+			cursor:     1 + len(inputFieldTestThumbs),
+			key:        tcell.KeyBackspace2,
+			wantText:   "a",
+			wantCursor: 1,
+		},
+		{
+			name:       "backspace after ZWJ sequence",
+			text:       inputFieldTestCoder + "b",
+			cursor:     len(inputFieldTestCoder),
+			key:        tcell.KeyBackspace,
+			wantText:   "b",
+			wantCursor: 0,
+		},
+		{
+			name:       "delete before combining mark",
+			text:       "a" + inputFieldTestAccent + "b",
+			cursor:     1,
+			key:        tcell.KeyDelete,
+			wantText:   "ab",
+			wantCursor: 1,
+		},
+		{
+			name:       "delete before ZWJ sequence",
+			text:       "a" + inputFieldTestCoder,
+			cursor:     1,
+			key:        tcell.KeyDelete,
+			wantText:   "a",
+			wantCursor: 1,
+		},
+		{
+			name:       "delete at end",
+			text:       "a" + inputFieldTestAccent,
+			cursor:     1 + len(inputFieldTestAccent),
+			key:        tcell.KeyDelete,
+			wantText:   "a" + inputFieldTestAccent,
+			wantCursor: 1 + len(inputFieldTestAccent),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := NewInputField().SetText(test.text)
+			i.cursorPos = test.cursor
+			i.InputHandler()(tcell.NewEventKey(test.key, 0, tcell.ModNone), func(p Primitive) {})
+			if i.GetText() != test.wantText || i.cursorPos != test.wantCursor {
+				t.Errorf("got (%q, %d), want (%q, %d)", i.GetText(), i.cursorPos, test.wantText, test.wantCursor)
+			}
+		})
+	}
+}
+
+func TestInputFieldMaskedCursor(t *testing.T) {
+	text := "a" + inputFieldTestAccent + inputFieldTestCoder + "b"
+	tests := []struct {
+		name       string
+		cursor     int
+		wantColumn int
+	}{
+		{name: "start", cursor: 0, wantColumn: 0},
+		{name: "after ASCII", cursor: 1, wantColumn: 1},
+		{name: "after combining mark", cursor: 1 + len(inputFieldTestAccent), wantColumn: 2},
+		{name: "after ZWJ sequence", cursor: 1 + len(inputFieldTestAccent+inputFieldTestCoder), wantColumn: 3},
+		{name: "end", cursor: len(text), wantColumn: 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			screen := tcell.NewSimulationScreen("UTF-8")
+			if err := screen.Init(); err != nil {
+				t.Fatal(err)
+			}
+			defer screen.Fini()
+			screen.SetSize(10, 1)
+
+			i := NewInputField().SetMaskCharacter('*').SetText(text)
+			i.SetRect(0, 0, 10, 1)
+			i.Focus(func(p Primitive) {})
+			i.cursorPos = test.cursor
+			i.Draw(screen)
+
+			x, _, _ := screen.GetCursor()
+			if x != test.wantColumn {
+				t.Errorf("cursor at column %d, want %d", x, test.wantColumn)
+			}
+			for column := 0; column < 4; column++ {
+				if r, _, _, _ := screen.GetContent(column, 0); r != '*' {
+					t.Errorf("column %d shows %q, want '*'", column, r)
+				}
+			}
+			if r, _, _, _ := screen.GetContent(4, 0); r == '*' {
+				t.Errorf("column 4 shows a mask character, want one per character")
+			}
+		})
+	}
+}
+
+func TestInputFieldNewlineKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          tcell.Key
+		mod          tcell.ModMask
+		reject       bool // Whether a newline rejection handler is set.
+		wantDone     bool
+		wantRejected bool
+	}{
+		{name: "enter", key: tcell.KeyEnter, wantDone: true},
+		{name: "ctrl-j", key: tcell.KeyCtrlJ, wantDone: true},
+		{name: "shift-enter", key: tcell.KeyEnter, mod: tcell.ModShift, wantDone: true},
+		{name: "enter with rejection", key: tcell.KeyEnter, reject: true, wantDone: true},
+		{name: "ctrl-j with rejection", key: tcell.KeyCtrlJ, reject: true, wantRejected: true},
+		{name: "alt-enter with rejection", key: tcell.KeyEnter, mod: tcell.ModAlt, reject: true, wantRejected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var done []tcell.Key
+			var rejected bool
+			i := NewInputField().SetText("text").SetDoneFunc(func(key tcell.Key) {
+				done = append(done, key)
+			})
+			if test.reject {
+				i.SetFlash(0, nil).SetNewlineRejectedFunc(func() { rejected = true })
+			}
+			i.InputHandler()(tcell.NewEventKey(test.key, 0, test.mod), func(p Primitive) {})
+			if test.wantDone && (len(done) != 1 || done[0] != tcell.KeyEnter) {
+				t.Errorf("done handler received %v, want [KeyEnter]", done)
+			}
+			if !test.wantDone && len(done) > 0 {
+				t.Errorf("done handler received %v, want no call", done)
+			}
+			if rejected != test.wantRejected {
+				t.Errorf("rejection handler called: %t, want %t", rejected, test.wantRejected)
+			}
+			if i.GetText() != "text" {
+				t.Errorf("text changed to %q", i.GetText())
+			}
+		})
+	}
+}
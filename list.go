@@ -1,8 +1,8 @@
 package tview
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/derailed/tcell/v2"
 )
@@ -13,10 +13,31 @@ type listItem struct {
 	SecondaryText string // A secondary text to be shown underneath the main text.
 	Shortcut      rune   // The key to select the list item directly, 0 if there is no shortcut.
 	Selected      func() // The optional function which is called when the item is selected.
+
+	// Optional styles of the main and secondary text. If set to the zero value,
+	// the list's text colors are used.
+	MainStyle, SecondaryStyle tcell.Style
+
+	// An optional reference object.
+	Reference interface{}
 }
 
+// The time after the last keystroke after which a fuzzy search query is
+// discarded and the next keystroke starts a new query.
+const listSearchTimeout = time.Second
+
+// How list item shortcuts are displayed (see List.SetShortcutDisplay()).
+const (
+	ListShortcutParentheses = iota // "(q)" in front of the main text.
+	ListShortcutBrackets           // "[q]" in front of the main text.
+	ListShortcutRight              // "q" right-aligned after the main text.
+)
+
 // List displays rows of items, each of which can be selected.
 //
+// If fuzzy search is enabled (see SetFuzzySearch()), typing characters which
+// are not shortcuts selects the item which matches the typed text best.
+//
 // See https://github.com/rivo/tview/wiki/List for an example.
 type List struct {
 	*Box
@@ -24,9 +45,13 @@ type List struct {
 	// The items of the list.
 	items []*listItem
 
-	// The index of the currently selected item.
+	// The index of the currently selected item. -1 if no item is selected,
+	// which is only possible if selectionOptional is set.
 	currentItem int
 
+	// Whether the list may have no selected item.
+	selectionOptional bool
+
 	// Whether or not to show the secondary item texts.
 	showSecondaryText bool
 
@@ -39,12 +64,19 @@ type List struct {
 	// The item shortcut text color.
 	shortcutColor tcell.Color
 
+	// How shortcuts are displayed, one of the ListShortcut constants.
+	shortcutDisplay int
+
 	// The text color for selected items.
 	selectedTextColor tcell.Color
 
 	// The background color for selected items.
 	selectedBackgroundColor tcell.Color
 
+	// The text and background colors for selected items when the list does not
+	// have focus. If tcell.ColorDefault, the colors above are used.
+	selectedTextColorUnfocused, selectedBackgroundColorUnfocused tcell.Color
+
 	// If true, the selection is only shown when the list has focus.
 	selectedFocusOnly bool
 
@@ -54,6 +86,14 @@ type List struct {
 	// Whether or not navigating the list will wrap around.
 	wrapAround bool
 
+	// If true, the offset is adjusted to keep the selected item vertically
+	// centered.
+	selectedAlwaysCentered bool
+
+	// The index of the item which was centered during the last call to Draw()
+	// or -1 if none was.
+	centeredItem int
+
 	// The number of list items skipped at the top before the first item is
 	// drawn.
 	itemOffset int
@@ -75,8 +115,51 @@ type List struct {
 	// function will be called even if the list item defines its own callback.
 	selected func(index int, mainText, secondaryText string, shortcut rune)
 
+	// Variants of the "changed" and "selected" functions which also receive the
+	// item's reference object.
+	changedWithReference, selectedWithReference func(index int, mainText, secondaryText string, shortcut rune, reference interface{})
+
 	// An optional function which is called when the user presses the Escape key.
 	done func()
+
+	// If set to true, typing selects the best fuzzy match.
+	fuzzySearch bool
+
+	// The function which scores a main text against the search query.
+	fuzzyMatch func(query, text string) (score int, matched []int)
+
+	// The color of matched characters. If tcell.ColorDefault, matches are not
+	// highlighted.
+	fuzzyHighlightColor tcell.Color
+
+	// The current search query and the time of its last change.
+	searchQuery string
+	searchTime  time.Time
+
+	// The matched rune positions in item main texts for the current query.
+	searchMatches map[*listItem][]int
+
+	// If set to true, the user can move items with the mouse or with Alt-Up
+	// and Alt-Down.
+	reorderable bool
+
+	// The index of the item being dragged with the mouse (-1 if none) and the
+	// index it will be moved to when the mouse button is released.
+	dragItem, dropItem int
+
+	// An optional function which is called when the user moved an item.
+	reordered func(from, to int)
+
+	// The index of the item under the mouse pointer or -1 if there is none.
+	hoverItem int
+
+	// The style of the item under the mouse pointer. If tcell.Style{}, hovered
+	// items are not highlighted.
+	hoverStyle tcell.Style
+
+	// An optional function which is called when the mouse pointer moves onto
+	// a different item or off the items.
+	hovered func(index int)
 }
 
 // NewList returns a new form.
@@ -85,6 +168,9 @@ func NewList() *List {
 		Box:                     NewBox(),
 		showSecondaryText:       true,
 		wrapAround:              true,
+		centeredItem:            -1,
+		dragItem:                -1,
+		hoverItem:               -1,
 		mainTextColor:           Styles.PrimaryTextColor,
 		secondaryTextColor:      Styles.TertiaryTextColor,
 		shortcutColor:           Styles.SecondaryTextColor,
@@ -98,8 +184,18 @@ func NewList() *List {
 // from the back (-1 = last item, -2 = second-to-last item, and so on). Out of
 // range indices are clamped to the beginning/end.
 //
+// If the selection was made optional with SetSelectionOptional(), a negative
+// index clears the selection instead.
+//
 // Calling this function triggers a "changed" event if the selection changes.
 func (l *List) SetCurrentItem(index int) *List {
+	if l.selectionOptional && (index < 0 || len(l.items) == 0) {
+		if l.currentItem >= 0 {
+			l.currentItem = -1
+			l.fireChanged(-1)
+		}
+		return l
+	}
 	if index < 0 {
 		index = len(l.items) + index
 	}
@@ -110,9 +206,8 @@ func (l *List) SetCurrentItem(index int) *List {
 		index = 0
 	}
 
-	if index != l.currentItem && l.changed != nil {
-		item := l.items[index]
-		l.changed(index, item.MainText, item.SecondaryText, item.Shortcut)
+	if index != l.currentItem {
+		l.fireChanged(index)
 	}
 
 	l.currentItem = index
@@ -121,7 +216,8 @@ func (l *List) SetCurrentItem(index int) *List {
 }
 
 // GetCurrentItem returns the index of the currently selected list item,
-// starting at 0 for the first item.
+// starting at 0 for the first item. It returns -1 if no item is selected (see
+// SetSelectionOptional()).
 func (l *List) GetCurrentItem() int {
 	return l.currentItem
 }
@@ -174,21 +270,24 @@ func (l *List) RemoveItem(index int) *List {
 	// Remove item.
 	l.items = append(l.items[:index], l.items[index+1:]...)
 
-	// If there is nothing left, we're done.
+	// If there is nothing left, we're done. An optional selection is cleared.
 	if len(l.items) == 0 {
+		if l.selectionOptional && l.currentItem >= 0 {
+			l.currentItem = -1
+			l.fireChanged(-1)
+		}
 		return l
 	}
 
 	// Shift current item.
 	previousCurrentItem := l.currentItem
-	if l.currentItem >= index {
+	if l.currentItem >= index && l.currentItem > 0 {
 		l.currentItem--
 	}
 
 	// Fire "changed" event for removed items.
-	if previousCurrentItem == index && l.changed != nil {
-		item := l.items[l.currentItem]
-		l.changed(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
+	if previousCurrentItem == index {
+		l.fireChanged(l.currentItem)
 	}
 
 	return l
@@ -212,18 +311,73 @@ func (l *List) SetShortcutColor(color tcell.Color) *List {
 	return l
 }
 
-// SetSelectedTextColor sets the text color of selected items.
+// SetShortcutDisplay sets how the items' shortcuts are displayed, one of
+// the following:
+//
+//   - ListShortcutParentheses: In parentheses in a column in front of the main
+//     texts, e.g. "(q) Quit" (the default).
+//   - ListShortcutBrackets: In brackets in a column in front of the main
+//     texts, e.g. "[q] Quit".
+//   - ListShortcutRight: Right-aligned in a column at the right edge of the
+//     list, e.g. "Quit     q".
+//
+// The shortcut column is only shown if at least one item has a shortcut. It
+// is as wide as the widest shortcut.
+func (l *List) SetShortcutDisplay(display int) *List {
+	l.shortcutDisplay = display
+	return l
+}
+
+// shortcutText returns the text displayed for the given shortcut, with any
+// brackets escaped.
+func (l *List) shortcutText(shortcut rune) string {
+	switch l.shortcutDisplay {
+	case ListShortcutBrackets:
+		return Escape("[" + string(shortcut) + "]")
+	case ListShortcutRight:
+		return Escape(string(shortcut))
+	default:
+		return Escape("(" + string(shortcut) + ")")
+	}
+}
+
+// SetSelectedTextColor sets the text color of selected items. It replaces the
+// item's main text color (see SetMainTextColor() and SetItemStyle()) but not
+// colors set with color tags. Provide tcell.ColorDefault to keep the item's
+// text colors and only change the background of selected items.
 func (l *List) SetSelectedTextColor(color tcell.Color) *List {
 	l.selectedTextColor = color
 	return l
 }
 
 // SetSelectedBackgroundColor sets the background color of selected items.
+// Provide tcell.ColorDefault to keep the item's background and only change the
+// text color of selected items.
 func (l *List) SetSelectedBackgroundColor(color tcell.Color) *List {
 	l.selectedBackgroundColor = color
 	return l
 }
 
+// SetSelectedTextColorUnfocused sets the text color of selected items while
+// the list does not have focus, e.g. to dim the selection of inactive lists.
+// If set to tcell.ColorDefault (the default), the color set with
+// SetSelectedTextColor() is used. This has no effect if the selection is only
+// shown when the list has focus (see SetSelectedFocusOnly()).
+func (l *List) SetSelectedTextColorUnfocused(color tcell.Color) *List {
+	l.selectedTextColorUnfocused = color
+	return l
+}
+
+// SetSelectedBackgroundColorUnfocused sets the background color of selected
+// items while the list does not have focus. If set to tcell.ColorDefault (the
+// default), the color set with SetSelectedBackgroundColor() is used. This has
+// no effect if the selection is only shown when the list has focus (see
+// SetSelectedFocusOnly()).
+func (l *List) SetSelectedBackgroundColorUnfocused(color tcell.Color) *List {
+	l.selectedBackgroundColorUnfocused = color
+	return l
+}
+
 // SetSelectedFocusOnly sets a flag which determines when the currently selected
 // list item is highlighted. If set to true, selected items are only highlighted
 // when the list has focus. If set to false, they are always highlighted.
@@ -232,6 +386,17 @@ func (l *List) SetSelectedFocusOnly(focusOnly bool) *List {
 	return l
 }
 
+// SetSelectedAlwaysCentered sets a flag which determines whether the list
+// scrolls such that the selected item stays vertically centered when the
+// selection changes. Near the beginning or the end of the list, the offset is
+// clamped so that no empty space is shown. If set to false (the default), the
+// list only scrolls as much as needed to keep the selected item in view.
+func (l *List) SetSelectedAlwaysCentered(centered bool) *List {
+	l.selectedAlwaysCentered = centered
+	l.centeredItem = -1
+	return l
+}
+
 // SetHighlightFullLine sets a flag which determines whether the colored
 // background of selected items spans the entire width of the view. If set to
 // true, the highlight spans the entire view. If set to false, only the text of
@@ -241,6 +406,53 @@ func (l *List) SetHighlightFullLine(highlight bool) *List {
 	return l
 }
 
+// SetHoverStyle sets the style with which the item under the mouse pointer is
+// highlighted, e.g. to preview which item a click would select. Colors set to
+// tcell.ColorDefault keep the item's colors and the style's attributes are
+// added to the item's attributes. The selected item is drawn with the
+// selection colors instead. Set to tcell.Style{} (the default) to disable the
+// highlight.
+//
+// This requires mouse support (see Application.EnableMouse()). See
+// SetHoverFunc() for when the highlight is removed.
+func (l *List) SetHoverStyle(style tcell.Style) *List {
+	l.hoverStyle = style
+	return l
+}
+
+// SetHoverFunc sets a handler which is called when the mouse pointer moves
+// onto a different list item, without clicking, e.g. to show a preview of the
+// item. It receives the index of the item under the pointer or -1 when the
+// pointer leaves the items. This does not change the selection.
+//
+// This requires mouse support (see Application.EnableMouse()). The list only
+// notices that the pointer left it when it receives a mouse move outside its
+// area. Containers such as Flex or Grid only pass on mouse events within
+// their own area so the item may remain hovered if the pointer leaves the
+// list and its container at once.
+func (l *List) SetHoverFunc(handler func(index int)) *List {
+	l.hovered = handler
+	return l
+}
+
+// GetHoveredItem returns the index of the item under the mouse pointer or -1
+// if there is none.
+func (l *List) GetHoveredItem() int {
+	return l.hoverItem
+}
+
+// setHoverItem sets the item under the mouse pointer and calls the hover
+// handler if it changed.
+func (l *List) setHoverItem(index int) {
+	if index == l.hoverItem {
+		return
+	}
+	l.hoverItem = index
+	if l.hovered != nil {
+		l.hovered(index)
+	}
+}
+
 // ShowSecondaryText determines whether or not to show secondary item texts.
 func (l *List) ShowSecondaryText(show bool) *List {
 	l.showSecondaryText = show
@@ -257,6 +469,45 @@ func (l *List) SetWrapAround(wrapAround bool) *List {
 	return l
 }
 
+// SetSelectionOptional sets whether the list may have no selected item. If set
+// to true, SetCurrentItem() with a negative index clears the selection and
+// GetCurrentItem() then returns -1. If the list has no items yet, nothing is
+// selected initially. Otherwise, the current selection is kept until it is
+// cleared. Navigating with the keyboard from an empty selection selects the
+// first (or, upwards, the last) item. Setting this to false selects the first
+// item if nothing is selected.
+func (l *List) SetSelectionOptional(optional bool) *List {
+	l.selectionOptional = optional
+	if optional && len(l.items) == 0 {
+		l.currentItem = -1
+	} else if !optional && l.currentItem < 0 {
+		l.currentItem = 0
+	}
+	return l
+}
+
+// SetReorderable sets whether the user can change the order of the list items.
+// If set to true, items can be dragged to a new position with the mouse and
+// the selected item can be moved up and down with Alt-Up and Alt-Down. While an
+// item is dragged, the item at the position where it will be dropped is
+// underlined. Each move is reported to the handler set with
+// SetReorderedFunc().
+func (l *List) SetReorderable(reorderable bool) *List {
+	l.reorderable = reorderable
+	if !reorderable {
+		l.dragItem = -1
+	}
+	return l
+}
+
+// SetReorderedFunc sets a function which is called when the user moved an item
+// (see SetReorderable()). It receives the item's previous index and its new
+// index. The item has already been moved when the function is called.
+func (l *List) SetReorderedFunc(handler func(from, to int)) *List {
+	l.reordered = handler
+	return l
+}
+
 // SetChangedFunc sets the function which is called when the user navigates to
 // a list item. The function receives the item's index in the list of items
 // (starting with 0), its main text, secondary text, and its shortcut rune.
@@ -277,6 +528,193 @@ func (l *List) SetSelectedFunc(handler func(int, string, string, rune)) *List {
 	return l
 }
 
+// SetChangedFuncWithReference is like SetChangedFunc() but the function also
+// receives the item's reference object (see SetItemReference()). It may be
+// used in addition to the function set with SetChangedFunc(), in which case
+// both are called.
+func (l *List) SetChangedFuncWithReference(handler func(index int, mainText, secondaryText string, shortcut rune, reference interface{})) *List {
+	l.changedWithReference = handler
+	return l
+}
+
+// SetSelectedFuncWithReference is like SetSelectedFunc() but the function
+// also receives the item's reference object (see SetItemReference()). It may
+// be used in addition to the function set with SetSelectedFunc(), in which
+// case both are called.
+func (l *List) SetSelectedFuncWithReference(handler func(index int, mainText, secondaryText string, shortcut rune, reference interface{})) *List {
+	l.selectedWithReference = handler
+	return l
+}
+
+// fireChanged calls the "changed" functions for the item with the given index.
+func (l *List) fireChanged(index int) {
+	if index < 0 {
+		// The selection was cleared.
+		if l.changed != nil {
+			l.changed(-1, "", "", 0)
+		}
+		if l.changedWithReference != nil {
+			l.changedWithReference(-1, "", "", 0, nil)
+		}
+		return
+	}
+	item := l.items[index]
+	if l.changed != nil {
+		l.changed(index, item.MainText, item.SecondaryText, item.Shortcut)
+	}
+	if l.changedWithReference != nil {
+		l.changedWithReference(index, item.MainText, item.SecondaryText, item.Shortcut, item.Reference)
+	}
+}
+
+// fireSelected calls the "selected" functions (but not the item's own
+// callback) for the item with the given index.
+func (l *List) fireSelected(index int) {
+	item := l.items[index]
+	if l.selected != nil {
+		l.selected(index, item.MainText, item.SecondaryText, item.Shortcut)
+	}
+	if l.selectedWithReference != nil {
+		l.selectedWithReference(index, item.MainText, item.SecondaryText, item.Shortcut, item.Reference)
+	}
+}
+
+// SetFuzzySearch sets whether the user can type to search items. The typed
+// characters form a query which is matched against the items' main texts
+// (without color tags) and the item with the best match is selected. By
+// default, an item matches if it contains the query's characters in the same
+// order, ignoring case, e.g. "cfg" matches "config.yaml". Matches where the
+// characters are closer together rank higher. See SetFuzzyMatchFunc() for
+// custom scoring.
+//
+// Shortcuts take precedence over the search. A space only extends a query
+// which was already started, otherwise it selects the current item as usual.
+// Backspace removes the last character from the query and Escape discards the
+// query. The query is also discarded when another key is pressed or after one
+// second without typing.
+func (l *List) SetFuzzySearch(enabled bool) *List {
+	l.fuzzySearch = enabled
+	l.resetSearch()
+	return l
+}
+
+// SetFuzzyMatchFunc sets the function which determines how well an item's main
+// text (without color tags) matches the search query (see SetFuzzySearch()).
+// It returns a score, where higher is better and negative values mean the text
+// does not match, and optionally the indices of the runes of the text which
+// matched the query, for highlighting. If several items have the best score,
+// the first of them is selected. Provide nil to restore the default matcher.
+func (l *List) SetFuzzyMatchFunc(matcher func(query, text string) (score int, matched []int)) *List {
+	l.fuzzyMatch = matcher
+	return l
+}
+
+// SetFuzzyHighlightColor sets the color of the characters of the item texts
+// which matched the search query (see SetFuzzySearch()). The default,
+// tcell.ColorDefault, disables highlighting.
+func (l *List) SetFuzzyHighlightColor(color tcell.Color) *List {
+	l.fuzzyHighlightColor = color
+	return l
+}
+
+// resetSearch discards the current search query.
+func (l *List) resetSearch() {
+	l.searchQuery = ""
+	l.searchMatches = nil
+}
+
+// handleSearchKey processes the given key event for fuzzy search. It returns
+// true if the event was consumed.
+func (l *List) handleSearchKey(event *tcell.EventKey) bool {
+	if time.Since(l.searchTime) > listSearchTimeout {
+		l.resetSearch()
+	}
+	switch event.Key() {
+	case tcell.KeyRune:
+		ch := event.Rune()
+		if event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) != 0 || ch == ' ' && l.searchQuery == "" {
+			break
+		}
+		for _, item := range l.items {
+			if item.Shortcut == ch {
+				l.resetSearch()
+				return false
+			}
+		}
+		l.search(l.searchQuery + string(ch))
+		return true
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if l.searchQuery != "" {
+			query := []rune(l.searchQuery)
+			l.search(string(query[:len(query)-1]))
+			return true
+		}
+	case tcell.KeyEscape:
+		if l.searchQuery != "" {
+			l.resetSearch()
+			return true
+		}
+	}
+	l.resetSearch()
+	return false
+}
+
+// search selects the item which matches the given query best.
+func (l *List) search(query string) {
+	l.searchQuery = query
+	l.searchTime = time.Now()
+	l.searchMatches = make(map[*listItem][]int)
+	if query == "" {
+		return
+	}
+	match := l.fuzzyMatch
+	if match == nil {
+		match = fuzzyMatch
+	}
+	best, bestScore := -1, 0
+	for index, item := range l.items {
+		score, matched := match(query, stripTags(item.MainText))
+		if score < 0 {
+			continue
+		}
+		l.searchMatches[item] = matched
+		if best < 0 || score > bestScore {
+			best, bestScore = index, score
+		}
+	}
+	if best >= 0 && best != l.currentItem {
+		l.currentItem = best
+		l.fireChanged(best)
+	}
+}
+
+// fuzzyMatch is the default matcher for List's fuzzy search. It matches if
+// all runes of the query appear in the text in the same order, ignoring case.
+// Compact matches (where the matched runes are close together) that start
+// early in the text score higher.
+func fuzzyMatch(query, text string) (score int, matched []int) {
+	queryRunes := []rune(strings.ToLower(query))
+	next := 0
+	for index, r := range []rune(strings.ToLower(text)) {
+		if next >= len(queryRunes) {
+			break
+		}
+		if r == queryRunes[next] {
+			matched = append(matched, index)
+			next++
+		}
+	}
+	if next < len(queryRunes) {
+		return -1, nil
+	}
+	span := matched[len(matched)-1] - matched[0] + 1
+	score = 100000 - 100*span - matched[0]
+	if score < 0 {
+		score = 0
+	}
+	return score, matched
+}
+
 // SetDoneFunc sets a function which is called when the user presses the Escape
 // key.
 func (l *List) SetDoneFunc(handler func()) *List {
@@ -342,15 +780,58 @@ func (l *List) InsertItem(index int, mainText, secondaryText string, shortcut ru
 	}
 	l.items[index] = item
 
-	// Fire a "change" event for the first item in the list.
-	if len(l.items) == 1 && l.changed != nil {
-		item := l.items[0]
-		l.changed(0, item.MainText, item.SecondaryText, item.Shortcut)
+	// Fire a "change" event for the first item in the list, unless nothing is
+	// selected.
+	if len(l.items) == 1 && l.currentItem == 0 {
+		l.fireChanged(0)
+	}
+
+	return l
+}
+
+// MoveItem moves the item at index "from" to index "to", shifting the items in
+// between. Out of range indices are ignored. The currently selected item is
+// shifted accordingly, i.e. it stays selected, and a "changed" event is fired
+// if its index changes.
+func (l *List) MoveItem(from, to int) *List {
+	if from < 0 || from >= len(l.items) || to < 0 || to >= len(l.items) || from == to {
+		return l
+	}
+	item := l.items[from]
+	if from < to {
+		copy(l.items[from:to], l.items[from+1:to+1])
+	} else {
+		copy(l.items[to+1:from+1], l.items[to:from])
+	}
+	l.items[to] = item
+
+	// Shift current item.
+	previousItem := l.currentItem
+	if l.currentItem == from {
+		l.currentItem = to
+	} else if from < l.currentItem && l.currentItem <= to {
+		l.currentItem--
+	} else if to <= l.currentItem && l.currentItem < from {
+		l.currentItem++
+	}
+	if l.currentItem != previousItem {
+		l.fireChanged(l.currentItem)
 	}
 
 	return l
 }
 
+// reorder moves an item on behalf of the user and notifies the handler.
+func (l *List) reorder(from, to int) {
+	if from == to || from < 0 || from >= len(l.items) || to < 0 || to >= len(l.items) {
+		return
+	}
+	l.MoveItem(from, to)
+	if l.reordered != nil {
+		l.reordered(from, to)
+	}
+}
+
 // GetItemCount returns the number of items in the list.
 func (l *List) GetItemCount() int {
 	return len(l.items)
@@ -371,6 +852,41 @@ func (l *List) SetItemText(index int, main, secondary string) *List {
 	return l
 }
 
+// SetItemStyle sets the styles of an item's main and secondary text,
+// overriding the list's main and secondary text colors for this item (see
+// SetMainTextColor() and SetSecondaryTextColor()). Provide tcell.StyleDefault
+// to revert to the list's colors. The selection highlight is still applied to
+// the main text of the selected item. Panics if the index is out of range.
+//
+// If the style's background color is tcell.ColorDefault, the list's
+// background shows through.
+func (l *List) SetItemStyle(index int, main, secondary tcell.Style) *List {
+	item := l.items[index]
+	item.MainStyle = main
+	item.SecondaryStyle = secondary
+	return l
+}
+
+// GetItemStyle returns the styles of an item's main and secondary text as set
+// with SetItemStyle(). Panics if the index is out of range.
+func (l *List) GetItemStyle(index int) (main, secondary tcell.Style) {
+	return l.items[index].MainStyle, l.items[index].SecondaryStyle
+}
+
+// SetItemReference stores a reference of any type in the item with the given
+// index, e.g. the object the item represents. Panics if the index is out of
+// range.
+func (l *List) SetItemReference(index int, reference interface{}) *List {
+	l.items[index].Reference = reference
+	return l
+}
+
+// GetItemReference returns the reference object of the item with the given
+// index as set with SetItemReference(). Panics if the index is out of range.
+func (l *List) GetItemReference(index int) interface{} {
+	return l.items[index].Reference
+}
+
 // FindItems searches the main and secondary texts for the given strings and
 // returns a list of item indices in which those strings are found. One of the
 // two search strings may be empty, it will then be ignored. Indices are always
@@ -414,7 +930,11 @@ func (l *List) FindItems(mainSearch, secondarySearch string, mustContainBoth, ig
 // Clear removes all items from the list.
 func (l *List) Clear() *List {
 	l.items = nil
+	l.hoverItem = -1
 	l.currentItem = 0
+	if l.selectionOptional {
+		l.currentItem = -1
+	}
 	return l
 }
 
@@ -430,27 +950,64 @@ func (l *List) Draw(screen tcell.Screen) {
 		bottomLimit = totalHeight
 	}
 
+	// The colors of the selected item.
+	hasFocus := l.HasFocus()
+	selectedTextColor, selectedBackgroundColor := l.selectedTextColor, l.selectedBackgroundColor
+	if !hasFocus {
+		if l.selectedTextColorUnfocused != tcell.ColorDefault {
+			selectedTextColor = l.selectedTextColorUnfocused
+		}
+		if l.selectedBackgroundColorUnfocused != tcell.ColorDefault {
+			selectedBackgroundColor = l.selectedBackgroundColorUnfocused
+		}
+	}
+
 	// Do we show any shortcuts?
-	var showShortcuts bool
+	var shortcutX, shortcutWidth int
 	for _, item := range l.items {
 		if item.Shortcut != 0 {
-			showShortcuts = true
-			x += 4
-			width -= 4
-			break
+			if w := TaggedStringWidth(l.shortcutText(item.Shortcut)); w > shortcutWidth {
+				shortcutWidth = w
+			}
+		}
+	}
+	showShortcuts := shortcutWidth > 0 && shortcutWidth < width
+	if showShortcuts {
+		if l.shortcutDisplay == ListShortcutRight {
+			width -= shortcutWidth + 1
+			shortcutX = x + width + 1
+		} else {
+			shortcutX = x
+			x += shortcutWidth + 1
+			width -= shortcutWidth + 1
 		}
 	}
 
 	// Adjust offset to keep the current selection in view.
-	if l.currentItem < l.itemOffset {
-		l.itemOffset = l.currentItem
-	} else if l.showSecondaryText {
-		if 2*(l.currentItem-l.itemOffset) >= height-1 {
-			l.itemOffset = (2*l.currentItem + 3 - height) / 2
-		}
-	} else {
-		if l.currentItem-l.itemOffset >= height {
-			l.itemOffset = l.currentItem + 1 - height
+	if l.currentItem >= 0 {
+		if l.selectedAlwaysCentered && l.currentItem != l.centeredItem {
+			visibleItems := height
+			if l.showSecondaryText {
+				visibleItems = height / 2
+			}
+			l.itemOffset = l.currentItem - visibleItems/2
+			if l.itemOffset > len(l.items)-visibleItems {
+				l.itemOffset = len(l.items) - visibleItems
+			}
+			if l.itemOffset < 0 {
+				l.itemOffset = 0
+			}
+			l.centeredItem = l.currentItem
+		} else if l.currentItem < l.itemOffset {
+			l.itemOffset = l.currentItem
+		} else if l.showSecondaryText {
+			if 2*(l.currentItem-l.itemOffset) >= height-1 {
+				l.itemOffset = (2*l.currentItem + 3 - height) / 2
+			}
+		} else {
+			if l.currentItem-l.itemOffset >= height {
+				l.itemOffset = l.currentItem + 1 - height
+			}
 		}
 	}
 	if l.horizontalOffset < 0 {
@@ -473,11 +1030,16 @@ func (l *List) Draw(screen tcell.Screen) {
 
 		// Shortcuts.
 		if showShortcuts && item.Shortcut != 0 {
-			Print(screen, fmt.Sprintf("(%s)", string(item.Shortcut)), x-5, y, 4, AlignRight, l.shortcutColor)
+			Print(screen, l.shortcutText(item.Shortcut), shortcutX, y, shortcutWidth, AlignRight, l.shortcutColor)
 		}
 
 		// Main text.
-		_, printedWidth, _, end := printWithStyle(screen, item.MainText, x, y, l.horizontalOffset, width, AlignLeft, tcell.StyleDefault.Foreground(l.mainTextColor), true)
+		mainStyle := tcell.StyleDefault.Foreground(l.mainTextColor)
+		if item.MainStyle != tcell.StyleDefault {
+			mainStyle = item.MainStyle
+		}
+		mainColor, mainBackground, _ := mainStyle.Decompose()
+		_, printedWidth, _, end := printWithStyle(screen, item.MainText, x, y, l.horizontalOffset, width, AlignLeft, mainStyle, mainBackground == tcell.ColorDefault)
 		if printedWidth > maxWidth {
 			maxWidth = printedWidth
 		}
@@ -485,8 +1047,28 @@ func (l *List) Draw(screen tcell.Screen) {
 			overflowing = true
 		}
 
+		// Highlight characters matching the search query.
+		if matched := l.searchMatches[item]; len(matched) > 0 && l.fuzzyHighlightColor != tcell.ColorDefault {
+			var runeIndex, next int
+			iterateString(stripTags(item.MainText), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				runes := 1 + len(comb)
+				for next < len(matched) && matched[next] < runeIndex {
+					next++
+				}
+				if next < len(matched) && matched[next] < runeIndex+runes {
+					if column := screenPos - l.horizontalOffset; column >= 0 && column < width {
+						m, c, style, _ := screen.GetContent(x+column, y)
+						screen.SetContent(x+column, y, m, c, style.Foreground(l.fuzzyHighlightColor))
+					}
+				}
+				runeIndex += runes
+				return screenPos-l.horizontalOffset >= width
+			})
+		}
+
 		// Background color of selected text.
-		if index == l.currentItem && (!l.selectedFocusOnly || l.HasFocus()) {
+		selected := index == l.currentItem && (!l.selectedFocusOnly || hasFocus)
+		if selected {
 			textWidth := width
 			if !l.highlightFullLine {
 				if w := TaggedStringWidth(item.MainText); w < textWidth {
@@ -497,14 +1079,47 @@ func (l *List) Draw(screen tcell.Screen) {
 			for bx := 0; bx < textWidth; bx++ {
 				m, c, style, _ := screen.GetContent(x+bx, y)
 				fg, _, _ := style.Decompose()
-				if fg == l.mainTextColor {
-					fg = l.selectedTextColor
+				if selectedTextColor != tcell.ColorDefault && (fg == l.mainTextColor || fg == mainColor) {
+					style = style.Foreground(selectedTextColor)
+				}
+				if selectedBackgroundColor != tcell.ColorDefault {
+					style = style.Background(selectedBackgroundColor)
 				}
-				style = style.Background(l.selectedBackgroundColor).Foreground(fg)
 				screen.SetContent(x+bx, y, m, c, style)
 			}
 		}
 
+		// Highlight the item under the mouse pointer.
+		if index == l.hoverItem && !selected && l.hoverStyle != (tcell.Style{}) {
+			textWidth := width
+			if !l.highlightFullLine {
+				if w := TaggedStringWidth(item.MainText); w < textWidth {
+					textWidth = w
+				}
+			}
+
+			hoverColor, hoverBackground, hoverAttributes := l.hoverStyle.Decompose()
+			for bx := 0; bx < textWidth; bx++ {
+				m, c, style, _ := screen.GetContent(x+bx, y)
+				_, _, attributes := style.Decompose()
+				if hoverColor != tcell.ColorDefault {
+					style = style.Foreground(hoverColor)
+				}
+				if hoverBackground != tcell.ColorDefault {
+					style = style.Background(hoverBackground)
+				}
+				screen.SetContent(x+bx, y, m, c, style.Attributes(attributes|hoverAttributes))
+			}
+		}
+
+		// Underline the item where a dragged item will be dropped.
+		if index == l.dropItem && l.dragItem >= 0 && l.dragItem != l.dropItem {
+			for bx := 0; bx < width; bx++ {
+				m, c, style, _ := screen.GetContent(x+bx, y)
+				screen.SetContent(x+bx, y, m, c, style.Underline(true))
+			}
+		}
+
 		y++
 
 		if y >= bottomLimit {
@@ -513,7 +1128,12 @@ func (l *List) Draw(screen tcell.Screen) {
 
 		// Secondary text.
 		if l.showSecondaryText {
-			_, printedWidth, _, end := printWithStyle(screen, item.SecondaryText, x, y, l.horizontalOffset, width, AlignLeft, tcell.StyleDefault.Foreground(l.secondaryTextColor), true)
+			secondaryStyle := tcell.StyleDefault.Foreground(l.secondaryTextColor)
+			if item.SecondaryStyle != tcell.StyleDefault {
+				secondaryStyle = item.SecondaryStyle
+			}
+			_, secondaryBackground, _ := secondaryStyle.Decompose()
+			_, printedWidth, _, end := printWithStyle(screen, item.SecondaryText, x, y, l.horizontalOffset, width, AlignLeft, secondaryStyle, secondaryBackground == tcell.ColorDefault)
 			if printedWidth > maxWidth {
 				maxWidth = printedWidth
 			}
@@ -537,6 +1157,10 @@ func (l *List) Draw(screen tcell.Screen) {
 // InputHandler returns the handler for this primitive.
 func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if l.fuzzySearch && l.handleSearchKey(event) {
+			return
+		}
+
 		if event.Key() == tcell.KeyEscape {
 			if l.done != nil {
 				l.done()
@@ -546,12 +1170,27 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			return
 		}
 
+		// Move the selected item.
+		if l.reorderable && event.Modifiers()&tcell.ModAlt != 0 && l.currentItem >= 0 {
+			switch event.Key() {
+			case tcell.KeyUp:
+				l.reorder(l.currentItem, l.currentItem-1)
+				return
+			case tcell.KeyDown:
+				l.reorder(l.currentItem, l.currentItem+1)
+				return
+			}
+		}
+
 		previousItem := l.currentItem
 
 		switch key := event.Key(); key {
 		case tcell.KeyTab, tcell.KeyDown:
 			l.currentItem++
 		case tcell.KeyBacktab, tcell.KeyUp:
+			if l.currentItem < 0 {
+				l.currentItem = len(l.items) // Start from the end.
+			}
 			l.currentItem--
 		case tcell.KeyRight:
 			if l.overflowing {
@@ -581,9 +1220,7 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 				if item.Selected != nil {
 					item.Selected()
 				}
-				if l.selected != nil {
-					l.selected(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
-				}
+				l.fireSelected(l.currentItem)
 			}
 		case tcell.KeyRune:
 			ch := event.Rune()
@@ -602,16 +1239,19 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 					break
 				}
 			}
+			if l.currentItem < 0 {
+				break // Nothing to select.
+			}
 			item := l.items[l.currentItem]
 			if item.Selected != nil {
 				item.Selected()
 			}
-			if l.selected != nil {
-				l.selected(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
-			}
+			l.fireSelected(l.currentItem)
 		}
 
-		if l.currentItem < 0 {
+		if l.selectionOptional && l.currentItem == -1 && previousItem == -1 {
+			// The key didn't select anything, keep the empty selection.
+		} else if l.currentItem < 0 {
 			if l.wrapAround {
 				l.currentItem = len(l.items) - 1
 			} else {
@@ -625,9 +1265,8 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			}
 		}
 
-		if l.currentItem != previousItem && l.currentItem < len(l.items) && l.changed != nil {
-			item := l.items[l.currentItem]
-			l.changed(l.currentItem, item.MainText, item.SecondaryText, item.Shortcut)
+		if l.currentItem != previousItem && l.currentItem < len(l.items) {
+			l.fireChanged(l.currentItem)
 		}
 	})
 }
@@ -655,12 +1294,55 @@ func (l *List) indexAtPoint(x, y int) int {
 // MouseHandler returns the mouse handler for this primitive.
 func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Drag an item.
+		if l.dragItem >= 0 {
+			switch action {
+			case MouseMove:
+				_, y := event.Position()
+				rectX, rectY, _, height := l.GetInnerRect()
+				if y < rectY {
+					y = rectY
+				} else if y >= rectY+height {
+					y = rectY + height - 1
+				}
+				l.dropItem = l.indexAtPoint(rectX, y)
+				if l.dropItem < 0 {
+					l.dropItem = len(l.items) - 1 // Below the last item.
+				}
+				return true, l
+			case MouseLeftUp:
+				from, to := l.dragItem, l.dropItem
+				l.dragItem = -1
+				l.reorder(from, to)
+				return true, nil
+			}
+		}
+
+		// Track the item under the mouse pointer. Moves are not consumed so
+		// that other primitives (e.g. other lists) receive them, too, and
+		// moves outside the list clear the hovered item.
+		if action == MouseMove && (l.hovered != nil || l.hoverStyle != (tcell.Style{})) {
+			index := -1
+			if l.InRect(event.Position()) {
+				index = l.indexAtPoint(event.Position())
+			}
+			l.setHoverItem(index)
+		}
+
 		if !l.InRect(event.Position()) {
 			return false, nil
 		}
 
 		// Process mouse event.
 		switch action {
+		case MouseLeftDown:
+			if l.reorderable {
+				if index := l.indexAtPoint(event.Position()); index >= 0 {
+					setFocus(l)
+					l.dragItem, l.dropItem = index, index
+					return true, l
+				}
+			}
 		case MouseLeftClick:
 			setFocus(l)
 			index := l.indexAtPoint(event.Position())
@@ -669,11 +1351,9 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 				if item.Selected != nil {
 					item.Selected()
 				}
-				if l.selected != nil {
-					l.selected(index, item.MainText, item.SecondaryText, item.Shortcut)
-				}
-				if index != l.currentItem && l.changed != nil {
-					l.changed(index, item.MainText, item.SecondaryText, item.Shortcut)
+				l.fireSelected(index)
+				if index != l.currentItem {
+					l.fireChanged(index)
 				}
 				l.currentItem = index
 			}
@@ -694,6 +1374,11 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			consumed = true
 		}
 
+		// Scrolling moves a different item under the mouse pointer.
+		if l.hoverItem >= 0 && (action == MouseScrollUp || action == MouseScrollDown) {
+			l.setHoverItem(l.indexAtPoint(event.Position()))
+		}
+
 		return
 	})
 }
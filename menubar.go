@@ -0,0 +1,587 @@
+package tview
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// MenuItem is one entry of a Menu. An item with an empty label is drawn as a
+// separator and cannot be selected.
+type MenuItem struct {
+	// The text to be displayed for this item.
+	Label string
+
+	// An optional text shown right-aligned next to the label, typically
+	// describing a keyboard shortcut (e.g. "Ctrl-S"). This is for display only,
+	// installing the actual key binding is up to the application.
+	Shortcut string
+
+	// The optional function which is called when the item is selected.
+	Selected func()
+
+	// An optional submenu which opens when this item is selected.
+	Submenu *Menu
+}
+
+// Menu is a named list of items which is opened from a MenuBar or from another
+// menu's item (as a submenu).
+type Menu struct {
+	// The title of the menu as shown in the menu bar.
+	Title string
+
+	// The items of this menu.
+	Items []*MenuItem
+}
+
+// NewMenu returns a new, empty menu with the given title.
+func NewMenu(title string) *Menu {
+	return &Menu{Title: title}
+}
+
+// AddItem adds a new item to the menu. The "shortcut" text is shown next to the
+// label and may be empty. The "selected" callback may be nil.
+func (m *Menu) AddItem(label, shortcut string, selected func()) *Menu {
+	m.Items = append(m.Items, &MenuItem{
+		Label:    label,
+		Shortcut: shortcut,
+		Selected: selected,
+	})
+	return m
+}
+
+// AddSubmenu adds a new item to the menu which opens the given submenu when
+// selected.
+func (m *Menu) AddSubmenu(label string, submenu *Menu) *Menu {
+	m.Items = append(m.Items, &MenuItem{
+		Label:   label,
+		Submenu: submenu,
+	})
+	return m
+}
+
+// AddSeparator adds a horizontal line to the menu which separates groups of
+// items.
+func (m *Menu) AddSeparator() *Menu {
+	m.Items = append(m.Items, &MenuItem{})
+	return m
+}
+
+// menuPopup describes a menu popup as it was drawn the last time.
+type menuPopup struct {
+	menu                *Menu
+	x, y, width, height int
+}
+
+// MenuBar is a one-line bar of menu titles (e.g. "File  Edit  View"). Selecting
+// a title opens its menu as a popup below the bar. Menu items may open
+// submenus which are shown to the right of their parent menu.
+//
+// The following keys can be used while the menu bar has focus:
+//
+//   - Left arrow, right arrow: Move to the previous/next menu.
+//   - Enter, down arrow: Open the current menu.
+//   - Alt-letter: Open the menu whose title starts with the letter.
+//
+// While a menu is open:
+//
+//   - Up arrow, down arrow: Move to the previous/next item.
+//   - Right arrow: Open the current item's submenu or move to the next menu.
+//   - Left arrow: Close the current submenu or move to the previous menu.
+//   - Enter: Select the current item.
+//   - Letter: Select the item whose label starts with the letter.
+//   - Escape: Close the current (sub)menu.
+//
+// Note that the open menus are drawn outside the menu bar's rectangle. The
+// menu bar should therefore be drawn after the primitives it overlaps. Flex and
+// Grid do this implicitly when the menu bar has focus.
+type MenuBar struct {
+	*Box
+
+	// The menus of the bar.
+	menus []*Menu
+
+	// The index of the current menu.
+	currentMenu int
+
+	// The indices of the current items, one for each open menu (the current
+	// menu and its open submenus). Empty if no menu is open.
+	currentItems []int
+
+	// The x-coordinates and widths of the menu titles as of the last time the
+	// bar was drawn.
+	titlePositions, titleWidths []int
+
+	// The open menu popups as of the last time the bar was drawn.
+	popups []menuPopup
+
+	// The color of the menu titles and item labels.
+	textColor tcell.Color
+
+	// The color of the item shortcut texts.
+	shortcutColor tcell.Color
+
+	// The text and background color of the current menu title and item.
+	selectedTextColor, selectedBackgroundColor tcell.Color
+
+	// The background color of the menu popups.
+	popupBackgroundColor tcell.Color
+
+	// An optional function which is called when the user presses Escape, Tab,
+	// or Backtab while no menu is open.
+	done func(key tcell.Key)
+}
+
+// NewMenuBar returns a new, empty menu bar.
+func NewMenuBar() *MenuBar {
+	return &MenuBar{
+		Box:                     NewBox().SetBackgroundColor(Styles.ContrastBackgroundColor),
+		textColor:               Styles.PrimaryTextColor,
+		shortcutColor:           Styles.SecondaryTextColor,
+		selectedTextColor:       Styles.PrimitiveBackgroundColor,
+		selectedBackgroundColor: Styles.PrimaryTextColor,
+		popupBackgroundColor:    Styles.MoreContrastBackgroundColor,
+	}
+}
+
+// AddMenu adds a menu to the right end of the menu bar.
+func (m *MenuBar) AddMenu(menu *Menu) *MenuBar {
+	m.menus = append(m.menus, menu)
+	return m
+}
+
+// GetMenuCount returns the number of menus in the menu bar.
+func (m *MenuBar) GetMenuCount() int {
+	return len(m.menus)
+}
+
+// Clear removes all menus from the menu bar.
+func (m *MenuBar) Clear() *MenuBar {
+	m.menus = nil
+	m.currentMenu = 0
+	m.currentItems = nil
+	return m
+}
+
+// OpenMenu opens the menu with the given index. This can be used to bind a key
+// to a menu while the menu bar does not have focus. Note that the application
+// must still move the focus to the menu bar for the user to navigate the menu.
+func (m *MenuBar) OpenMenu(index int) *MenuBar {
+	if index < 0 || index >= len(m.menus) {
+		return m
+	}
+	m.currentMenu = index
+	m.currentItems = []int{m.firstSelectable(m.menus[index], 0, 1)}
+	return m
+}
+
+// CloseMenu closes all open menus.
+func (m *MenuBar) CloseMenu() *MenuBar {
+	m.currentItems = nil
+	return m
+}
+
+// IsOpen returns whether or not a menu is currently open.
+func (m *MenuBar) IsOpen() bool {
+	return len(m.currentItems) > 0
+}
+
+// SetTextColor sets the color of the menu titles and item labels.
+func (m *MenuBar) SetTextColor(color tcell.Color) *MenuBar {
+	m.textColor = color
+	return m
+}
+
+// SetShortcutColor sets the color of the menu items' shortcut texts.
+func (m *MenuBar) SetShortcutColor(color tcell.Color) *MenuBar {
+	m.shortcutColor = color
+	return m
+}
+
+// SetSelectedTextColor sets the text color of the current menu title and item.
+func (m *MenuBar) SetSelectedTextColor(color tcell.Color) *MenuBar {
+	m.selectedTextColor = color
+	return m
+}
+
+// SetSelectedBackgroundColor sets the background color of the current menu
+// title and item.
+func (m *MenuBar) SetSelectedBackgroundColor(color tcell.Color) *MenuBar {
+	m.selectedBackgroundColor = color
+	return m
+}
+
+// SetPopupBackgroundColor sets the background color of the open menus.
+func (m *MenuBar) SetPopupBackgroundColor(color tcell.Color) *MenuBar {
+	m.popupBackgroundColor = color
+	return m
+}
+
+// SetDoneFunc sets a handler which is called when the user presses the Escape,
+// Tab, or Backtab key while no menu is open.
+func (m *MenuBar) SetDoneFunc(handler func(key tcell.Key)) *MenuBar {
+	m.done = handler
+	return m
+}
+
+// openMenu returns the deepest open menu or nil if no menu is open.
+func (m *MenuBar) openMenu() *Menu {
+	if len(m.currentItems) == 0 || m.currentMenu >= len(m.menus) {
+		return nil
+	}
+	menu := m.menus[m.currentMenu]
+	for _, index := range m.currentItems[:len(m.currentItems)-1] {
+		menu = menu.Items[index].Submenu
+	}
+	return menu
+}
+
+// firstSelectable returns the index of the first selectable item of the given
+// menu, starting at index "start" and moving in the given direction (1 or -1),
+// wrapping around. Separators are skipped. If there is no selectable item,
+// "start" is returned.
+func (m *MenuBar) firstSelectable(menu *Menu, start, direction int) int {
+	count := len(menu.Items)
+	for step := 0; step < count; step++ {
+		index := ((start+direction*step)%count + count) % count
+		if menu.Items[index].Label != "" {
+			return index
+		}
+	}
+	return start
+}
+
+// switchMenu moves to the menu at the given offset from the current menu,
+// keeping it open if a menu was open before.
+func (m *MenuBar) switchMenu(offset int) {
+	if len(m.menus) == 0 {
+		return
+	}
+	index := ((m.currentMenu+offset)%len(m.menus) + len(m.menus)) % len(m.menus)
+	if m.IsOpen() {
+		m.OpenMenu(index)
+	} else {
+		m.currentMenu = index
+	}
+}
+
+// activate selects the current item of the deepest open menu. If it has a
+// submenu, the submenu is opened. Otherwise, all menus are closed and the
+// item's callback is invoked.
+func (m *MenuBar) activate() {
+	menu := m.openMenu()
+	if menu == nil || len(menu.Items) == 0 {
+		return
+	}
+	item := menu.Items[m.currentItems[len(m.currentItems)-1]]
+	if item.Label == "" {
+		return // Separators can't be selected.
+	}
+	if item.Submenu != nil {
+		if len(item.Submenu.Items) > 0 {
+			m.currentItems = append(m.currentItems, m.firstSelectable(item.Submenu, 0, 1))
+		}
+		return
+	}
+	m.currentItems = nil
+	if item.Selected != nil {
+		item.Selected()
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (m *MenuBar) Draw(screen tcell.Screen) {
+	m.Box.DrawForSubclass(screen, m)
+
+	x, y, width, height := m.GetInnerRect()
+	if height < 1 || width <= 0 {
+		return
+	}
+
+	// Draw the menu titles.
+	m.titlePositions, m.titleWidths = m.titlePositions[:0], m.titleWidths[:0]
+	titleX := x
+	for index, menu := range m.menus {
+		title := " " + menu.Title + " "
+		titleWidth := TaggedStringWidth(title)
+		m.titlePositions = append(m.titlePositions, titleX)
+		m.titleWidths = append(m.titleWidths, titleWidth)
+		if titleX >= x+width {
+			continue
+		}
+		color := m.textColor
+		if index == m.currentMenu && (m.HasFocus() || m.IsOpen()) {
+			color = m.selectedTextColor
+			selectedStyle := tcell.StyleDefault.Background(m.selectedBackgroundColor)
+			for bx := 0; bx < titleWidth && titleX+bx < x+width; bx++ {
+				screen.SetContent(titleX+bx, y, ' ', nil, selectedStyle)
+			}
+		}
+		Print(screen, title, titleX, y, x+width-titleX, AlignLeft, color)
+		titleX += titleWidth
+	}
+
+	// Draw the open menus.
+	m.popups = m.popups[:0]
+	if !m.IsOpen() || m.currentMenu >= len(m.menus) {
+		return
+	}
+	popupX, popupY := m.titlePositions[m.currentMenu], y+1
+	menu := m.menus[m.currentMenu]
+	for level, current := range m.currentItems {
+		popupX, popupY = m.drawPopup(screen, menu, current, popupX, popupY)
+		if level < len(m.currentItems)-1 {
+			menu = menu.Items[current].Submenu
+		}
+	}
+}
+
+// drawPopup draws the given menu as a popup with its top-left corner at the
+// given position, highlighting the item with the index "current". It returns
+// the position at which a submenu of the current item is to be drawn.
+func (m *MenuBar) drawPopup(screen tcell.Screen, menu *Menu, current, x, y int) (int, int) {
+	// How much space do we need?
+	var labelWidth, shortcutWidth int
+	for _, item := range menu.Items {
+		w := TaggedStringWidth(item.Label)
+		if item.Submenu != nil {
+			w += 2
+		}
+		if w > labelWidth {
+			labelWidth = w
+		}
+		if w := TaggedStringWidth(item.Shortcut); w > shortcutWidth {
+			shortcutWidth = w
+		}
+	}
+	if shortcutWidth > 0 {
+		shortcutWidth += 2
+	}
+	width, height := labelWidth+shortcutWidth+4, len(menu.Items)+2
+
+	// Keep the popup on screen.
+	screenWidth, screenHeight := screen.Size()
+	if x+width > screenWidth {
+		x = screenWidth - width
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y+height > screenHeight {
+		height = screenHeight - y
+	}
+	m.popups = append(m.popups, menuPopup{menu: menu, x: x, y: y, width: width, height: height})
+
+	// Draw the frame.
+	frame := NewBox().SetBorder(true).SetBackgroundColor(m.popupBackgroundColor)
+	frame.SetBorderColor(m.textColor)
+	frame.SetRect(x, y, width, height)
+	frame.Draw(screen)
+
+	// Draw the items.
+	innerWidth := width - 2
+	background := tcell.StyleDefault.Background(m.popupBackgroundColor)
+	for index, item := range menu.Items {
+		itemY := y + 1 + index
+		if itemY >= y+height-1 {
+			break
+		}
+		if item.Label == "" {
+			lineStyle := background.Foreground(m.textColor)
+			for bx := 0; bx < innerWidth; bx++ {
+				screen.SetContent(x+1+bx, itemY, Borders.Horizontal, nil, lineStyle)
+			}
+			screen.SetContent(x, itemY, Borders.LeftT, nil, lineStyle)
+			screen.SetContent(x+width-1, itemY, Borders.RightT, nil, lineStyle)
+			continue
+		}
+		labelColor, shortcutColor := m.textColor, m.shortcutColor
+		if index == current {
+			labelColor, shortcutColor = m.selectedTextColor, m.selectedTextColor
+			selectedStyle := tcell.StyleDefault.Background(m.selectedBackgroundColor)
+			for bx := 0; bx < innerWidth; bx++ {
+				screen.SetContent(x+1+bx, itemY, ' ', nil, selectedStyle)
+			}
+		}
+		Print(screen, item.Label, x+2, itemY, innerWidth-2, AlignLeft, labelColor)
+		if item.Submenu != nil {
+			Print(screen, ">", x+1, itemY, innerWidth-1, AlignRight, labelColor)
+		} else if item.Shortcut != "" {
+			Print(screen, item.Shortcut, x+1, itemY, innerWidth-1, AlignRight, shortcutColor)
+		}
+	}
+
+	return x + width - 1, y + 1 + current
+}
+
+// InputHandler returns the handler for this primitive.
+func (m *MenuBar) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if len(m.menus) == 0 {
+			if key := event.Key(); m.done != nil && (key == tcell.KeyEscape || key == tcell.KeyTab || key == tcell.KeyBacktab) {
+				m.done(key)
+			}
+			return
+		}
+
+		// Alt-letter opens menus by their mnemonic.
+		if event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt > 0 {
+			for index, menu := range m.menus {
+				if hasMnemonic(menu.Title, event.Rune()) {
+					m.OpenMenu(index)
+					return
+				}
+			}
+			return
+		}
+
+		// No menu is open.
+		menu := m.openMenu()
+		if menu == nil {
+			switch key := event.Key(); key {
+			case tcell.KeyLeft:
+				m.switchMenu(-1)
+			case tcell.KeyRight:
+				m.switchMenu(1)
+			case tcell.KeyEnter, tcell.KeyDown:
+				m.OpenMenu(m.currentMenu)
+			case tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab:
+				if m.done != nil {
+					m.done(key)
+				}
+			}
+			return
+		}
+
+		// A menu is open.
+		level := len(m.currentItems) - 1
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyBacktab:
+			if len(menu.Items) > 0 {
+				m.currentItems[level] = m.firstSelectable(menu, m.currentItems[level]-1, -1)
+			}
+		case tcell.KeyDown, tcell.KeyTab:
+			if len(menu.Items) > 0 {
+				m.currentItems[level] = m.firstSelectable(menu, m.currentItems[level]+1, 1)
+			}
+		case tcell.KeyRight:
+			if len(menu.Items) > 0 && menu.Items[m.currentItems[level]].Submenu != nil {
+				m.activate()
+			} else {
+				m.switchMenu(1)
+			}
+		case tcell.KeyLeft:
+			if level > 0 {
+				m.currentItems = m.currentItems[:level]
+			} else {
+				m.switchMenu(-1)
+			}
+		case tcell.KeyEnter:
+			m.activate()
+		case tcell.KeyEscape:
+			m.currentItems = m.currentItems[:level]
+		case tcell.KeyRune:
+			for index, item := range menu.Items {
+				if item.Label != "" && hasMnemonic(item.Label, event.Rune()) {
+					m.currentItems[level] = index
+					m.activate()
+					break
+				}
+			}
+		}
+	})
+}
+
+// hasMnemonic returns whether or not the first letter of the given text
+// (ignoring color tags) matches the given rune, ignoring case.
+func hasMnemonic(text string, ch rune) bool {
+	text = strings.TrimSpace(stripTags(text))
+	for _, r := range text {
+		return unicode.ToLower(r) == unicode.ToLower(ch)
+	}
+	return false
+}
+
+// Focus is called when this primitive receives focus.
+func (m *MenuBar) Focus(delegate func(p Primitive)) {
+	m.Box.Focus(delegate)
+}
+
+// Blur is called when this primitive loses focus.
+func (m *MenuBar) Blur() {
+	m.currentItems = nil
+	m.Box.Blur()
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (m *MenuBar) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		_, rectY, _, _ := m.GetInnerRect()
+
+		// Find the menu title or the popup item under the mouse.
+		titleIndex := -1
+		if y == rectY && m.InRect(x, y) {
+			for index, titleX := range m.titlePositions {
+				if x >= titleX && x < titleX+m.titleWidths[index] {
+					titleIndex = index
+					break
+				}
+			}
+		}
+		popupLevel, itemIndex := -1, -1
+		for level := len(m.popups) - 1; level >= 0; level-- {
+			popup := m.popups[level]
+			if x >= popup.x && x < popup.x+popup.width && y >= popup.y && y < popup.y+popup.height {
+				popupLevel = level
+				if index := y - popup.y - 1; index >= 0 && index < len(popup.menu.Items) && y < popup.y+popup.height-1 {
+					itemIndex = index
+				}
+				break
+			}
+		}
+		if titleIndex < 0 && popupLevel < 0 {
+			if m.IsOpen() && action == MouseLeftClick {
+				m.CloseMenu() // Clicked outside the menus.
+				return true, nil
+			}
+			return m.IsOpen(), nil
+		}
+
+		switch action {
+		case MouseLeftClick:
+			if !m.HasFocus() {
+				setFocus(m) // Only if needed because blurring closes the menus.
+			}
+			if titleIndex >= 0 {
+				if m.IsOpen() && titleIndex == m.currentMenu {
+					m.CloseMenu()
+				} else {
+					m.OpenMenu(titleIndex)
+				}
+			} else if itemIndex >= 0 && popupLevel < len(m.currentItems) {
+				m.currentItems = append(m.currentItems[:popupLevel], itemIndex)
+				m.activate()
+			}
+			consumed = true
+		case MouseMove:
+			if !m.IsOpen() {
+				break
+			}
+			if titleIndex >= 0 && titleIndex != m.currentMenu {
+				m.OpenMenu(titleIndex)
+			} else if itemIndex >= 0 && popupLevel < len(m.currentItems) && m.popups[popupLevel].menu.Items[itemIndex].Label != "" {
+				m.currentItems = append(m.currentItems[:popupLevel], itemIndex)
+			}
+			consumed = true
+		default:
+			consumed = true
+		}
+
+		if m.IsOpen() {
+			capture = m
+		}
+		return
+	})
+}
@@ -24,6 +24,10 @@ type Modal struct {
 	// The text color.
 	textColor tcell.Color
 
+	// Whether the buttons are stacked vertically instead of being placed side
+	// by side.
+	buttonsVertical bool
+
 	// The optional callback for when the user clicked one of the buttons. It
 	// receives the index of the clicked button and the button's label.
 	done func(buttonIndex int, buttonLabel string)
@@ -77,6 +81,17 @@ func (m *Modal) SetButtonTextColor(color tcell.Color) *Modal {
 	return m
 }
 
+// SetButtonsVertical sets whether the buttons are stacked on top of each other
+// (one per row) instead of being placed side by side. This is useful when
+// there are many buttons or their labels are long. All buttons then have the
+// width of the widest button and are centered. The user can navigate them with
+// the up and down keys.
+func (m *Modal) SetButtonsVertical(vertical bool) *Modal {
+	m.buttonsVertical = vertical
+	m.form.buttonsVertical = vertical
+	return m
+}
+
 // SetDoneFunc sets a handler which is called when one of the buttons was
 // pressed. It receives the index of the button as well as its label text. The
 // handler is also called when the user presses the Escape key. The index will
@@ -146,9 +161,17 @@ func (m *Modal) Draw(screen tcell.Screen) {
 	// Calculate the width of this modal.
 	buttonsWidth := 0
 	for _, button := range m.form.buttons {
-		buttonsWidth += TaggedStringWidth(button.label) + 4 + 2
+		if m.buttonsVertical {
+			if w := TaggedStringWidth(button.getText()) + 4; w > buttonsWidth {
+				buttonsWidth = w
+			}
+			continue
+		}
+		buttonsWidth += TaggedStringWidth(button.getText()) + 4 + 2
+	}
+	if !m.buttonsVertical {
+		buttonsWidth -= 2
 	}
-	buttonsWidth -= 2
 	screenWidth, screenHeight := screen.Size()
 	width := screenWidth / 3
 	if width < buttonsWidth {
@@ -165,6 +188,9 @@ func (m *Modal) Draw(screen tcell.Screen) {
 
 	// Set the modal's position and size.
 	height := len(lines) + 6
+	if m.buttonsVertical && len(m.form.buttons) > 1 {
+		height += len(m.form.buttons) - 1
+	}
 	width += 4
 	x := (screenWidth - width) / 2
 	y := (screenHeight - height) / 2
@@ -0,0 +1,383 @@
+package tview
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// Pager is a read-only viewer for long texts, similar to the "less" command.
+// It consists of a scrollable TextView and a status line at the bottom which
+// shows the scroll position and the state of the current search.
+//
+// Besides the navigation keys of TextView (arrows, "j", "k", "g", "G", Page
+// Up/Down, etc.), the following keys are supported:
+//
+//   - Space, "b": Scroll one page down/up.
+//   - "/": Enter a regular expression to search for. Enter starts the search,
+//     Escape cancels it.
+//   - "n", "N": Move to the next/previous match.
+//   - "#": Toggle line numbers.
+//   - Escape, "q": Call the "done" handler.
+//
+// The text is displayed as is, i.e. color and region tags are not interpreted.
+// The underlying TextView can be accessed with GetTextView() but note that its
+// text, regions, and gutter function are managed by the pager.
+type Pager struct {
+	*Box
+
+	// The text view displaying the text.
+	textView *TextView
+
+	// The lines of the text.
+	lines []string
+
+	// Whether to show line numbers.
+	lineNumbers bool
+
+	// Whether the user is currently entering a search pattern, and the pattern
+	// entered so far.
+	searching bool
+	query     string
+
+	// The compiled search pattern or nil if there is no active search.
+	pattern *regexp.Regexp
+
+	// The line of each match and the index of the current match.
+	matchLines   []int
+	currentMatch int
+
+	// A message to show in the status line, e.g. an invalid pattern error.
+	// Cleared with the next key press.
+	message string
+
+	// The style of the status line.
+	statusStyle tcell.Style
+
+	// The style of the line numbers.
+	lineNumberStyle tcell.Style
+
+	// An optional function which is called when the user presses Escape or
+	// "q".
+	done func(key tcell.Key)
+}
+
+// NewPager returns a new, empty pager.
+func NewPager() *Pager {
+	p := &Pager{
+		Box: NewBox(),
+		textView: NewTextView().
+			SetRegions(true).
+			SetWrap(true),
+		statusStyle: tcell.StyleDefault.
+			Background(Styles.ContrastBackgroundColor).
+			Foreground(Styles.PrimaryTextColor),
+		lineNumberStyle: tcell.StyleDefault.
+			Foreground(Styles.TertiaryTextColor),
+	}
+	p.textView.SetToggleHighlights(false)
+	return p
+}
+
+// GetTextView returns the text view which displays the text, e.g. to change its
+// colors or wrapping behavior.
+func (p *Pager) GetTextView() *TextView {
+	return p.textView
+}
+
+// SetText sets the text to be displayed and scrolls to its beginning. Any
+// active search is applied to the new text.
+func (p *Pager) SetText(text string) *Pager {
+	p.lines = strings.Split(text, "\n")
+	p.update()
+	p.textView.ScrollToBeginning()
+	return p
+}
+
+// GetText returns the text displayed by the pager.
+func (p *Pager) GetText() string {
+	return strings.Join(p.lines, "\n")
+}
+
+// SetLineNumbers sets whether line numbers are displayed to the left of the
+// text. The user can toggle them with the "#" key.
+func (p *Pager) SetLineNumbers(show bool) *Pager {
+	p.lineNumbers = show
+	if show {
+		p.textView.SetGutterFunc(p.lineNumber)
+	} else {
+		p.textView.SetGutterFunc(nil)
+	}
+	return p
+}
+
+// SetStatusStyle sets the style of the status line.
+func (p *Pager) SetStatusStyle(style tcell.Style) *Pager {
+	p.statusStyle = style
+	return p
+}
+
+// SetLineNumberStyle sets the style of the line numbers.
+func (p *Pager) SetLineNumberStyle(style tcell.Style) *Pager {
+	p.lineNumberStyle = style
+	return p
+}
+
+// SetDoneFunc sets a handler which is called when the user presses Escape or
+// "q" (while not entering a search pattern). The key which was pressed is
+// provided, tcell.KeyRune for "q".
+func (p *Pager) SetDoneFunc(handler func(key tcell.Key)) *Pager {
+	p.done = handler
+	return p
+}
+
+// Search highlights all matches of the given regular expression and scrolls
+// to the first match at or below the top of the visible area. An empty
+// pattern ends the current search. An error is returned if the pattern is not
+// a valid regular expression, in which case the current search is kept.
+func (p *Pager) Search(pattern string) error {
+	if pattern == "" {
+		p.pattern = nil
+		p.update()
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	p.pattern = re
+	p.update()
+
+	// Start with the first match which is currently visible or below.
+	top := p.topLine()
+	p.currentMatch = 0
+	for index, line := range p.matchLines {
+		if line >= top {
+			p.currentMatch = index
+			break
+		}
+	}
+	p.showMatch()
+	return nil
+}
+
+// NextMatch moves to the next match of the current search, wrapping around at
+// the end of the text.
+func (p *Pager) NextMatch() *Pager {
+	if len(p.matchLines) > 0 {
+		p.currentMatch = (p.currentMatch + 1) % len(p.matchLines)
+		p.showMatch()
+	}
+	return p
+}
+
+// PreviousMatch moves to the previous match of the current search, wrapping
+// around at the beginning of the text.
+func (p *Pager) PreviousMatch() *Pager {
+	if len(p.matchLines) > 0 {
+		p.currentMatch = (p.currentMatch + len(p.matchLines) - 1) % len(p.matchLines)
+		p.showMatch()
+	}
+	return p
+}
+
+// update rebuilds the text of the text view, marking all matches of the
+// current search pattern as regions.
+func (p *Pager) update() {
+	p.matchLines = p.matchLines[:0]
+	var b strings.Builder
+	for index, line := range p.lines {
+		if index > 0 {
+			b.WriteByte('\n')
+		}
+		var start int
+		if p.pattern != nil {
+			for _, match := range p.pattern.FindAllStringIndex(line, -1) {
+				if match[0] == match[1] {
+					continue // Empty matches cannot be highlighted.
+				}
+				fmt.Fprintf(&b, `%s["%d"]%s[""]`, Escape(line[start:match[0]]), len(p.matchLines), Escape(line[match[0]:match[1]]))
+				p.matchLines = append(p.matchLines, index)
+				start = match[1]
+			}
+		}
+		b.WriteString(Escape(line[start:]))
+	}
+	if p.currentMatch >= len(p.matchLines) {
+		p.currentMatch = 0
+	}
+	p.textView.Highlight()
+	p.textView.SetTextKeepScroll(b.String())
+}
+
+// showMatch highlights the current match and scrolls it into view.
+func (p *Pager) showMatch() {
+	if len(p.matchLines) == 0 {
+		p.textView.Highlight()
+		return
+	}
+	p.textView.Highlight(strconv.Itoa(p.currentMatch)).ScrollToHighlight()
+}
+
+// topLine returns the index of the text line shown at the top of the text
+// view.
+func (p *Pager) topLine() int {
+	p.textView.Lock()
+	defer p.textView.Unlock()
+	if p.textView.lineOffset >= 0 && p.textView.lineOffset < len(p.textView.index) {
+		return p.textView.index[p.textView.lineOffset].Line
+	}
+	row, _ := p.textView.GetScrollOffset()
+	return row
+}
+
+// lineNumber is the text view's gutter function when line numbers are shown.
+func (p *Pager) lineNumber(line int) (string, tcell.Style) {
+	digits := len(strconv.Itoa(len(p.lines)))
+	return fmt.Sprintf("%*d ", digits, line+1), p.lineNumberStyle
+}
+
+// Draw draws this primitive onto the screen.
+func (p *Pager) Draw(screen tcell.Screen) {
+	p.Box.DrawForSubclass(screen, p)
+	x, y, width, height := p.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the text.
+	p.textView.SetRect(x, y, width, height-1)
+	p.textView.Draw(screen)
+
+	// Draw the status line.
+	y += height - 1
+	for index := 0; index < width; index++ {
+		screen.SetContent(x+index, y, ' ', nil, p.statusStyle)
+	}
+	if p.searching {
+		_, drawnWidth, _, _ := printWithStyle(screen, Escape("/"+p.query), x, y, 0, width, AlignLeft, p.statusStyle, false)
+		if p.HasFocus() {
+			screen.ShowCursor(x+drawnWidth, y)
+		}
+		return
+	}
+
+	// Scroll position.
+	p.textView.Lock()
+	rows, offset, pageSize := len(p.textView.index), p.textView.lineOffset, p.textView.pageSize
+	p.textView.Unlock()
+	bottom := offset + pageSize
+	if bottom > rows {
+		bottom = rows
+	}
+	position := "100%"
+	if rows > 0 {
+		position = strconv.Itoa(bottom*100/rows) + "%"
+	}
+	_, positionWidth, _, _ := printWithStyle(screen, position, x, y, 0, width, AlignRight, p.statusStyle, false)
+
+	// Search state.
+	status := p.message
+	if status == "" && p.pattern != nil {
+		if len(p.matchLines) == 0 {
+			status = "/" + p.pattern.String() + " (no matches)"
+		} else {
+			status = fmt.Sprintf("/%s (%d/%d)", p.pattern.String(), p.currentMatch+1, len(p.matchLines))
+		}
+	}
+	if width > positionWidth+1 {
+		printWithStyle(screen, Escape(status), x, y, 0, width-positionWidth-1, AlignLeft, p.statusStyle, false)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (p *Pager) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return p.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		p.message = ""
+
+		// Enter a search pattern.
+		if p.searching {
+			switch event.Key() {
+			case tcell.KeyRune:
+				p.query += string(event.Rune())
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				if p.query == "" {
+					p.searching = false
+					break
+				}
+				runes := []rune(p.query)
+				p.query = string(runes[:len(runes)-1])
+			case tcell.KeyEnter:
+				p.searching = false
+				if err := p.Search(p.query); err != nil {
+					p.message = "Invalid pattern: " + err.Error()
+				}
+			case tcell.KeyEscape:
+				p.searching = false
+			}
+			return
+		}
+
+		switch key := event.Key(); key {
+		case tcell.KeyEscape:
+			if p.done != nil {
+				p.done(key)
+			}
+			return
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case '/':
+				p.searching = true
+				p.query = ""
+				return
+			case 'n':
+				p.NextMatch()
+				return
+			case 'N':
+				p.PreviousMatch()
+				return
+			case '#':
+				p.SetLineNumbers(!p.lineNumbers)
+				return
+			case ' ':
+				event = tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone)
+			case 'b':
+				event = tcell.NewEventKey(tcell.KeyPgUp, 0, tcell.ModNone)
+			case 'q':
+				if p.done != nil {
+					p.done(key)
+				}
+				return
+			}
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab:
+			return // Don't trigger the text view's "done" handler.
+		}
+
+		// Everything else scrolls the text view.
+		if handler := p.textView.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (p *Pager) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return p.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !p.InRect(event.Position()) {
+			return false, nil
+		}
+
+		// The pager keeps the focus itself.
+		consumed, capture = p.textView.MouseHandler()(action, event, func(Primitive) {
+			setFocus(p)
+		})
+		if action == MouseLeftClick {
+			setFocus(p)
+			consumed = true
+		}
+		return
+	})
+}
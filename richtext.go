@@ -0,0 +1,161 @@
+package tview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// RichTextBuilder assembles text containing color and region tags (see the
+// package documentation) from individual pieces. Text added with Text() or
+// Textf() is escaped so that any square brackets it contains are printed as
+// they are, while the other methods add the corresponding tags. Example:
+//
+//	text := tview.NewRichTextBuilder().
+//		Color(tcell.ColorYellow).Text("Warning: ").
+//		Reset().Text(message).
+//		String()
+//	textView.SetText(text)
+//
+// The builder is not safe for concurrent use.
+type RichTextBuilder struct {
+	// The tagged text assembled so far, without the pending text.
+	builder strings.Builder
+
+	// Text added since the last tag. It is escaped as a whole when the next
+	// tag is added so that brackets split across several calls to Text() are
+	// escaped, too.
+	pending strings.Builder
+}
+
+// NewRichTextBuilder returns a new, empty builder.
+func NewRichTextBuilder() *RichTextBuilder {
+	return &RichTextBuilder{}
+}
+
+// Text adds the given text. Square brackets in the text are escaped so they
+// are not interpreted as tags.
+func (r *RichTextBuilder) Text(text string) *RichTextBuilder {
+	r.pending.WriteString(text)
+	return r
+}
+
+// Textf adds the text resulting from formatting the arguments according to the
+// given format specifier (see fmt.Sprintf()). The result is escaped like the
+// text passed to Text().
+func (r *RichTextBuilder) Textf(format string, a ...interface{}) *RichTextBuilder {
+	fmt.Fprintf(&r.pending, format, a...)
+	return r
+}
+
+// Newline adds a line break.
+func (r *RichTextBuilder) Newline() *RichTextBuilder {
+	r.pending.WriteByte('\n')
+	return r
+}
+
+// Color sets the text color of the text which follows. tcell.ColorDefault
+// resets the text color to the default of the primitive displaying the text.
+func (r *RichTextBuilder) Color(color tcell.Color) *RichTextBuilder {
+	return r.tag("[" + colorTagName(color) + "::]")
+}
+
+// Background sets the background color of the text which follows.
+// tcell.ColorDefault resets the background color to the default of the
+// primitive displaying the text.
+func (r *RichTextBuilder) Background(color tcell.Color) *RichTextBuilder {
+	return r.tag("[:" + colorTagName(color) + ":]")
+}
+
+// Attributes sets the style attributes of the text which follows. Only bold,
+// blink, dim, reverse, and underline are supported, other attributes are
+// ignored. tcell.AttrNone resets the attributes to the default of the primitive
+// displaying the text.
+func (r *RichTextBuilder) Attributes(attributes tcell.AttrMask) *RichTextBuilder {
+	return r.tag("[::" + attributesTagName(attributes) + "]")
+}
+
+// Style sets the text color, background color, and attributes of the text
+// which follows, all at once.
+func (r *RichTextBuilder) Style(style tcell.Style) *RichTextBuilder {
+	foreground, background, attributes := style.Decompose()
+	return r.tag("[" + colorTagName(foreground) + ":" + colorTagName(background) + ":" + attributesTagName(attributes) + "]")
+}
+
+// Reset resets colors and attributes to the defaults of the primitive
+// displaying the text.
+func (r *RichTextBuilder) Reset() *RichTextBuilder {
+	return r.tag("[-:-:-]")
+}
+
+// Region starts a region with the given ID (see TextView.SetRegions()). The
+// region ends with the next call to Region() or EndRegion(). Region IDs may
+// contain letters, digits, and the characters "_,;: -.".
+func (r *RichTextBuilder) Region(id string) *RichTextBuilder {
+	return r.tag(`["` + id + `"]`)
+}
+
+// EndRegion ends the current region.
+func (r *RichTextBuilder) EndRegion() *RichTextBuilder {
+	return r.tag(`[""]`)
+}
+
+// String returns the text assembled so far.
+func (r *RichTextBuilder) String() string {
+	return r.builder.String() + Escape(r.pending.String())
+}
+
+// tag adds the given tag, after the pending text.
+func (r *RichTextBuilder) tag(tag string) *RichTextBuilder {
+	r.builder.WriteString(Escape(r.pending.String()))
+	r.pending.Reset()
+	r.builder.WriteString(tag)
+	return r
+}
+
+// colorTagName returns the representation of the given color in a color tag.
+// Named colors are represented by their name, other colors by their
+// hexadecimal RGB value. The default color is represented by "-".
+func colorTagName(color tcell.Color) string {
+	if color == tcell.ColorDefault || color.Hex() < 0 {
+		return "-"
+	}
+	if color&tcell.ColorIsRGB == 0 {
+		var name string
+		for n, c := range tcell.ColorNames {
+			if c == color && (name == "" || len(n) < len(name) || len(n) == len(name) && n < name) {
+				name = n
+			}
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("#%06x", color.Hex())
+}
+
+// attributesTagName returns the representation of the given attributes in a
+// color tag. If no supported attributes are set, "-" is returned.
+func attributesTagName(attributes tcell.AttrMask) string {
+	var flags string
+	if attributes&tcell.AttrBlink != 0 {
+		flags += "l"
+	}
+	if attributes&tcell.AttrBold != 0 {
+		flags += "b"
+	}
+	if attributes&tcell.AttrDim != 0 {
+		flags += "d"
+	}
+	if attributes&tcell.AttrReverse != 0 {
+		flags += "r"
+	}
+	if attributes&tcell.AttrUnderline != 0 {
+		flags += "u"
+	}
+	if flags == "" {
+		return "-"
+	}
+	return flags
+}
@@ -0,0 +1,412 @@
+package tview
+
+import (
+	"github.com/derailed/tcell/v2"
+)
+
+// ScrollView shows a window into a child primitive which may be larger than
+// the available space. The child is drawn onto a virtual area of a fixed size
+// (the content size) and the scroll view shows the part of it which is
+// currently scrolled into view, clipping everything else. An optional vertical
+// scroll bar indicates the position of the window.
+//
+// Popups drawn by the child outside of its own rectangle (e.g. drop-down lists
+// at the bottom of the content) are clipped as well.
+//
+// # Navigation
+//
+// When the scroll view itself has focus, the following keys can be used:
+//
+//   - h, left arrow: Scroll left.
+//   - l, right arrow: Scroll right.
+//   - j, down arrow: Scroll down.
+//   - k, up arrow: Scroll up.
+//   - g, home: Scroll to the top.
+//   - G, end: Scroll to the bottom.
+//   - Ctrl-F, page down: Scroll down by one page.
+//   - Ctrl-B, page up: Scroll up by one page.
+//
+// If the child has focus, all key events are forwarded to it. Mouse events
+// within the window are forwarded to the child with coordinates translated to
+// the virtual area. The mouse wheel scrolls the view if the child does not
+// consume it.
+type ScrollView struct {
+	*Box
+
+	// The primitive shown in this scroll view.
+	child Primitive
+
+	// The size of the virtual area the child is drawn onto.
+	contentWidth, contentHeight int
+
+	// The number of rows and columns of the content scrolled out of view at
+	// the top and on the left.
+	rowOffset, columnOffset int
+
+	// The height of the window as of the last call to Draw().
+	pageSize int
+
+	// The scroll bar visibility, one of the ScrollBar constants.
+	scrollBarVisibility int
+
+	// The colors of the scroll bar's track and thumb.
+	scrollBarColor, scrollBarThumbColor tcell.Color
+
+	// Whether the scroll bar was shown the last time the view was drawn.
+	scrollBarShown bool
+
+	// Whether the user is currently dragging the scroll bar's thumb.
+	scrollBarDragging bool
+
+	// Whether the child has captured the mouse.
+	childCapturing bool
+}
+
+// NewScrollView returns a new scroll view which shows the given child on a
+// virtual area of the given size. The child may be nil.
+func NewScrollView(child Primitive, contentWidth, contentHeight int) *ScrollView {
+	return &ScrollView{
+		Box:                 NewBox(),
+		child:               child,
+		contentWidth:        contentWidth,
+		contentHeight:       contentHeight,
+		scrollBarVisibility: ScrollBarAuto,
+		scrollBarColor:      Styles.ContrastBackgroundColor,
+		scrollBarThumbColor: Styles.SecondaryTextColor,
+	}
+}
+
+// SetContent sets the primitive shown in this scroll view and the size of the
+// virtual area it is drawn onto.
+func (s *ScrollView) SetContent(child Primitive, contentWidth, contentHeight int) *ScrollView {
+	s.child = child
+	s.contentWidth, s.contentHeight = contentWidth, contentHeight
+	return s
+}
+
+// GetContent returns the primitive shown in this scroll view.
+func (s *ScrollView) GetContent() Primitive {
+	return s.child
+}
+
+// SetContentSize sets the size of the virtual area the child is drawn onto.
+func (s *ScrollView) SetContentSize(width, height int) *ScrollView {
+	s.contentWidth, s.contentHeight = width, height
+	return s
+}
+
+// GetContentSize returns the size of the virtual area the child is drawn onto.
+func (s *ScrollView) GetContentSize() (width, height int) {
+	return s.contentWidth, s.contentHeight
+}
+
+// SetOffset sets the number of rows and columns of the content which are
+// scrolled out of view at the top and on the left. The values are clamped
+// when the view is drawn.
+func (s *ScrollView) SetOffset(row, column int) *ScrollView {
+	s.rowOffset, s.columnOffset = row, column
+	return s
+}
+
+// GetOffset returns the number of rows and columns of the content which are
+// scrolled out of view at the top and on the left.
+func (s *ScrollView) GetOffset() (row, column int) {
+	return s.rowOffset, s.columnOffset
+}
+
+// ScrollToBeginning scrolls to the top left corner of the content.
+func (s *ScrollView) ScrollToBeginning() *ScrollView {
+	s.rowOffset, s.columnOffset = 0, 0
+	return s
+}
+
+// ScrollToEnd scrolls to the bottom of the content.
+func (s *ScrollView) ScrollToEnd() *ScrollView {
+	s.rowOffset = s.contentHeight
+	return s
+}
+
+// SetScrollBarVisibility sets when a vertical scroll bar is shown on the right
+// edge of the scroll view, one of ScrollBarNever, ScrollBarAuto (the default,
+// only when the content does not fit), or ScrollBarAlways.
+func (s *ScrollView) SetScrollBarVisibility(visibility int) *ScrollView {
+	s.scrollBarVisibility = visibility
+	return s
+}
+
+// SetScrollBarColors sets the colors of the scroll bar's track and thumb.
+func (s *ScrollView) SetScrollBarColors(track, thumb tcell.Color) *ScrollView {
+	s.scrollBarColor = track
+	s.scrollBarThumbColor = thumb
+	return s
+}
+
+// window returns the screen area in which the content is shown, excluding the
+// scroll bar, as determined by the last call to Draw().
+func (s *ScrollView) window() (x, y, width, height int) {
+	x, y, width, height = s.GetInnerRect()
+	if s.scrollBarShown {
+		width--
+	}
+	return
+}
+
+// clampOffsets ensures the offsets are within the content for a window of the
+// given size.
+func (s *ScrollView) clampOffsets(width, height int) {
+	if s.rowOffset > s.contentHeight-height {
+		s.rowOffset = s.contentHeight - height
+	}
+	if s.rowOffset < 0 {
+		s.rowOffset = 0
+	}
+	if s.columnOffset > s.contentWidth-width {
+		s.columnOffset = s.contentWidth - width
+	}
+	if s.columnOffset < 0 {
+		s.columnOffset = 0
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (s *ScrollView) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+	x, y, width, height := s.GetInnerRect()
+	s.pageSize = height
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Reserve space for the scroll bar.
+	s.scrollBarShown = width > 1 && (s.scrollBarVisibility == ScrollBarAlways ||
+		s.scrollBarVisibility == ScrollBarAuto && s.contentHeight > height)
+	if s.scrollBarShown {
+		width--
+	}
+	s.clampOffsets(width, height)
+
+	// Draw the child onto the virtual area.
+	if s.child != nil {
+		s.child.SetRect(0, 0, s.contentWidth, s.contentHeight)
+		s.child.Draw(&clippedScreen{
+			Screen:        screen,
+			x:             x,
+			y:             y,
+			width:         width,
+			height:        height,
+			offsetX:       s.columnOffset,
+			offsetY:       s.rowOffset,
+			contentWidth:  s.contentWidth,
+			contentHeight: s.contentHeight,
+		})
+	}
+
+	// Draw the scroll bar.
+	if s.scrollBarShown {
+		drawScrollBar(screen, x+width, y, height, s.contentHeight, s.rowOffset, s.scrollBarColor, s.scrollBarThumbColor, s.backgroundColor)
+	}
+}
+
+// HasFocus returns whether or not this primitive or its child has focus.
+func (s *ScrollView) HasFocus() bool {
+	if s.child != nil && s.child.HasFocus() {
+		return true
+	}
+	return s.Box.HasFocus()
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *ScrollView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		// Forward events to the child if it has focus.
+		if s.child != nil && s.child.HasFocus() {
+			if handler := s.child.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+			return
+		}
+
+		switch event.Key() {
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'g':
+				s.rowOffset, s.columnOffset = 0, 0
+			case 'G':
+				s.rowOffset = s.contentHeight
+			case 'j':
+				s.rowOffset++
+			case 'k':
+				s.rowOffset--
+			case 'h':
+				s.columnOffset--
+			case 'l':
+				s.columnOffset++
+			}
+		case tcell.KeyHome:
+			s.rowOffset, s.columnOffset = 0, 0
+		case tcell.KeyEnd:
+			s.rowOffset = s.contentHeight
+		case tcell.KeyUp:
+			s.rowOffset--
+		case tcell.KeyDown:
+			s.rowOffset++
+		case tcell.KeyLeft:
+			s.columnOffset--
+		case tcell.KeyRight:
+			s.columnOffset++
+		case tcell.KeyPgDn, tcell.KeyCtrlF:
+			s.rowOffset += s.pageSize
+		case tcell.KeyPgUp, tcell.KeyCtrlB:
+			s.rowOffset -= s.pageSize
+		}
+		_, _, width, height := s.window()
+		s.clampOffsets(width, height)
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (s *ScrollView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return s.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		windowX, windowY, width, height := s.window()
+
+		// Drag the scroll bar's thumb.
+		if s.scrollBarDragging {
+			switch action {
+			case MouseMove:
+				s.rowOffset = scrollBarOffset(y-windowY, height, s.contentHeight)
+				return true, s
+			case MouseLeftUp:
+				s.scrollBarDragging = false
+				return true, nil
+			}
+		}
+
+		// Forward events to the child, in virtual coordinates.
+		inWindow := x >= windowX && x < windowX+width && y >= windowY && y < windowY+height
+		if s.child != nil && (inWindow || s.childCapturing) {
+			if handler := s.child.MouseHandler(); handler != nil {
+				translated := tcell.NewEventMouse(x-windowX+s.columnOffset, y-windowY+s.rowOffset, event.Buttons(), event.Modifiers())
+				consumed, capture = handler(action, translated, setFocus)
+				s.childCapturing = capture != nil
+				if capture != nil {
+					capture = s // We need to translate coordinates.
+				}
+				if consumed || s.childCapturing {
+					return
+				}
+			}
+		}
+
+		if !s.InRect(x, y) {
+			return false, nil
+		}
+
+		switch action {
+		case MouseLeftDown:
+			if s.scrollBarShown && x == windowX+width && y >= windowY && y < windowY+height {
+				setFocus(s)
+				s.scrollBarDragging = true
+				s.rowOffset = scrollBarOffset(y-windowY, height, s.contentHeight)
+				return true, s
+			}
+		case MouseLeftClick:
+			setFocus(s)
+			consumed = true
+		case MouseScrollUp:
+			s.rowOffset--
+			consumed = true
+		case MouseScrollDown:
+			s.rowOffset++
+			consumed = true
+		case MouseScrollLeft:
+			s.columnOffset--
+			consumed = true
+		case MouseScrollRight:
+			s.columnOffset++
+			consumed = true
+		}
+		s.clampOffsets(width, height)
+
+		return
+	})
+}
+
+// clippedScreen is a tcell.Screen which maps a virtual area onto a window of
+// an underlying screen. Everything drawn outside the window is discarded.
+type clippedScreen struct {
+	tcell.Screen
+
+	// The window on the underlying screen.
+	x, y, width, height int
+
+	// The position of the window within the virtual area.
+	offsetX, offsetY int
+
+	// The size of the virtual area.
+	contentWidth, contentHeight int
+}
+
+// translate maps virtual coordinates to screen coordinates. The last return
+// value is false if the position is outside the window.
+func (c *clippedScreen) translate(x, y int) (int, int, bool) {
+	x, y = x-c.offsetX, y-c.offsetY
+	if x < 0 || y < 0 || x >= c.width || y >= c.height {
+		return 0, 0, false
+	}
+	return c.x + x, c.y + y, true
+}
+
+// Size returns the size of the virtual area.
+func (c *clippedScreen) Size() (int, int) {
+	return c.contentWidth, c.contentHeight
+}
+
+// SetContent sets the contents of a cell if it is inside the window.
+func (c *clippedScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	if x, y, ok := c.translate(x, y); ok {
+		c.Screen.SetContent(x, y, mainc, combc, style)
+	}
+}
+
+// SetCell sets the contents of a cell if it is inside the window.
+func (c *clippedScreen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	if len(ch) > 0 {
+		c.SetContent(x, y, ch[0], ch[1:], style)
+	} else {
+		c.SetContent(x, y, ' ', nil, style)
+	}
+}
+
+// GetContent returns the contents of a cell. Cells outside the window are
+// empty.
+func (c *clippedScreen) GetContent(x, y int) (mainc rune, combc []rune, style tcell.Style, width int) {
+	if x, y, ok := c.translate(x, y); ok {
+		return c.Screen.GetContent(x, y)
+	}
+	return ' ', nil, tcell.StyleDefault, 1
+}
+
+// Fill fills the window with the given character and style.
+func (c *clippedScreen) Fill(ch rune, style tcell.Style) {
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			c.Screen.SetContent(c.x+x, c.y+y, ch, nil, style)
+		}
+	}
+}
+
+// Clear clears the window.
+func (c *clippedScreen) Clear() {
+	c.Fill(' ', tcell.StyleDefault)
+}
+
+// ShowCursor shows the cursor if it is inside the window and hides it
+// otherwise.
+func (c *clippedScreen) ShowCursor(x, y int) {
+	if x, y, ok := c.translate(x, y); ok {
+		c.Screen.ShowCursor(x, y)
+	} else {
+		c.Screen.HideCursor()
+	}
+}
@@ -0,0 +1,143 @@
+package tview
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// SnapshotCell describes a single screen cell as captured by SnapshotCells().
+type SnapshotCell struct {
+	// The primary rune of the cell.
+	Main rune
+
+	// Combining runes following the primary rune, if any.
+	Comb []rune
+
+	// The style the cell was drawn with.
+	Style tcell.Style
+
+	// The screen width of the cell's content. Wide characters have a width of
+	// 2, in which case the following cell is covered by this one.
+	Width int
+}
+
+// SnapshotCells draws the given primitive onto an off-screen simulation screen
+// of the given size and returns the resulting cells, one slice per row. The
+// primitive's rectangle is set to cover the entire screen. This is useful for
+// snapshot ("golden file") tests which need to check colors and attributes.
+// See Snapshot() for a text-only version.
+func SnapshotCells(p Primitive, width, height int) ([][]SnapshotCell, error) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		return nil, err
+	}
+	defer screen.Fini()
+	screen.SetSize(width, height)
+	p.SetRect(0, 0, width, height)
+	p.Draw(screen)
+	return screenCells(screen), nil
+}
+
+// screenCells returns the cells of the given screen, one slice per row.
+func screenCells(screen tcell.Screen) [][]SnapshotCell {
+	width, height := screen.Size()
+	rows := make([][]SnapshotCell, height)
+	for y := 0; y < height; y++ {
+		rows[y] = make([]SnapshotCell, width)
+		for x := 0; x < width; x++ {
+			main, comb, style, w := screen.GetContent(x, y)
+			if main == 0 {
+				main = ' '
+			}
+			rows[y][x] = SnapshotCell{Main: main, Comb: comb, Style: style, Width: w}
+		}
+	}
+	return rows
+}
+
+// Snapshot draws the given primitive onto an off-screen simulation screen of
+// the given size and returns the screen's text content, one line per row,
+// separated by newline characters. Colors and attributes are discarded. Cells
+// covered by wide characters are omitted so that each line reads as it does
+// in a terminal.
+//
+// Combined with Application.SetScreen(), this allows for snapshot tests of
+// applications without a real terminal.
+func Snapshot(p Primitive, width, height int) (string, error) {
+	rows, err := SnapshotCells(p, width, height)
+	if err != nil {
+		return "", err
+	}
+	return cellsText(rows, false), nil
+}
+
+// cellsText returns the text content of the given cells, one line per row. If
+// "ansi" is true, colors and attributes are included as ANSI escape sequences.
+func cellsText(rows [][]SnapshotCell, ansi bool) string {
+	var b strings.Builder
+	for y, row := range rows {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		var current tcell.Style
+		for x := 0; x < len(row); x++ {
+			cell := row[x]
+			if ansi && (x == 0 || cell.Style != current) {
+				b.WriteString(ansiStyle(cell.Style))
+				current = cell.Style
+			}
+			b.WriteRune(cell.Main)
+			for _, r := range cell.Comb {
+				b.WriteRune(r)
+			}
+			if cell.Width > 1 {
+				x += cell.Width - 1
+			}
+		}
+		if ansi {
+			b.WriteString("\x1b[0m")
+		}
+	}
+	return b.String()
+}
+
+// ansiStyle returns the ANSI escape sequence (SGR) which resets the terminal's
+// style and then selects the given style.
+func ansiStyle(style tcell.Style) string {
+	foreground, background, attributes := style.Decompose()
+	codes := []string{"0"}
+	for _, attribute := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attributes&attribute.mask != 0 {
+			codes = append(codes, attribute.code)
+		}
+	}
+	for index, color := range []tcell.Color{foreground, background} {
+		prefix := "38"
+		if index == 1 {
+			prefix = "48"
+		}
+		if color == tcell.ColorDefault || color&tcell.ColorValid == 0 {
+			continue
+		}
+		if color&tcell.ColorIsRGB != 0 {
+			r, g, b := color.RGB()
+			codes = append(codes, prefix+";2;"+strconv.Itoa(int(r))+";"+strconv.Itoa(int(g))+";"+strconv.Itoa(int(b)))
+		} else {
+			codes = append(codes, prefix+";5;"+strconv.Itoa(int(color-tcell.ColorValid)))
+		}
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
@@ -187,6 +187,16 @@ func (c *TableCell) SetClickedFunc(clicked func() bool) *TableCell {
 	return c
 }
 
+// TableBorders defines the runes used to draw the borders of a Table (see
+// Table.SetBordersRunes()). A value of 0 for any rune means the corresponding
+// rune in the global Borders variable is used.
+type TableBorders struct {
+	Horizontal, Vertical                       rune // Lines.
+	TopLeft, TopRight, BottomLeft, BottomRight rune // Outer corners.
+	LeftT, RightT, TopT, BottomT               rune // Junctions with the outer border.
+	Cross                                      rune // Junctions between cells.
+}
+
 // Table visualizes two-dimensional data consisting of rows and columns. Each
 // Table cell is defined via SetCell() by the TableCell type. They can be added
 // dynamically to the table and changed any time.
@@ -229,10 +239,19 @@ func (c *TableCell) SetClickedFunc(clicked func() bool) *TableCell {
 //   - Ctrl-F, page down: Move down by one page.
 //   - Ctrl-B, page up: Move up by one page.
 //
+// If column resizing is enabled (see SetColumnResizable()), the selected column
+// can be resized with "+" and "-" and reset with "=".
+//
 // When there is no selection, this affects the entire table (except for fixed
 // rows and columns). When there is a selection, the user moves the selection.
 // The class will attempt to keep the selection from moving out of the screen.
 //
+// Escape, Tab, and Backtab are passed to the handler set with SetDoneFunc(),
+// e.g. to move the focus to another primitive.
+//
+// If the table is editable (see SetEditable()), Enter opens an inline input
+// field to edit the selected cell's text.
+//
 // Use SetInputCapture() to override or modify keyboard input.
 //
 // See https://github.com/rivo/tview/wiki/Table for an example.
@@ -245,6 +264,13 @@ type Table struct {
 	// The color of the borders or the separator.
 	bordersColor tcell.Color
 
+	// The style of the borders or the separator. If this value is the empty
+	// struct, the borders color and the table's background color are used.
+	bordersStyle tcell.Style
+
+	// The runes used to draw the borders.
+	bordersRunes TableBorders
+
 	// If there are no borders, the column separator.
 	separator rune
 
@@ -261,6 +287,10 @@ type Table struct {
 	// The number of fixed rows / columns.
 	fixedRows, fixedColumns int
 
+	// The style of the cells in fixed rows and columns. If this value is the
+	// empty struct, these cells look like all others.
+	fixedStyle tcell.Style
+
 	// Whether or not rows or columns can be selected. If both are set to true,
 	// cells can be selected.
 	rowsSelectable, columnsSelectable bool
@@ -272,6 +302,10 @@ type Table struct {
 	// right.
 	rowOffset, columnOffset int
 
+	// The number of cells by which the content of the leftmost non-fixed
+	// column is scrolled to the right.
+	contentOffset int
+
 	// If set to true, the table's last row will always be visible.
 	trackEnd bool
 
@@ -285,10 +319,25 @@ type Table struct {
 	// drawn.
 	visibleColumnWidths []int
 
+	// Manual column widths, indexed by column. These override the widths
+	// calculated from the cell contents.
+	columnWidths map[int]int
+
+	// The minimum width a column can be resized to.
+	minColumnWidth int
+
+	// If set to true, the selected column can be resized with the keyboard.
+	columnResizable bool
+
 	// The style of the selected rows. If this value is the empty struct,
 	// selected rows are simply inverted.
 	selectedStyle tcell.Style
 
+	// The style of the current cell within a selected row or column. If this
+	// value is the empty struct, the cell is styled like the rest of the
+	// selection.
+	selectedCellStyle tcell.Style
+
 	// An optional function which gets called when the user presses Enter on a
 	// selected cell. If entire rows selected, the column value is undefined.
 	// Likewise for entire columns.
@@ -299,18 +348,38 @@ type Table struct {
 	// Likewise for entire columns.
 	selectionChanged func(row, column int)
 
+	// An optional function which gets called when the user clicks on a cell.
+	cellClicked func(row, column int, cell *TableCell) bool
+
+	// An optional function which decides whether a cell can be selected, in
+	// addition to the cell's own NotSelectable flag.
+	cellSelectable func(row, column int) bool
+
 	// An optional function which gets called when the user presses Escape, Tab,
 	// or Backtab. Also when the user presses Enter if nothing is selectable.
 	done func(key tcell.Key)
+
+	// If set to true, the user can edit the text of the selected cell.
+	editable bool
+
+	// The input field used to edit a cell or nil if no cell is being edited.
+	editor *InputField
+
+	// The position of the cell being edited.
+	editRow, editColumn int
+
+	// An optional function which gets called when the user has edited a cell.
+	cellEdited func(row, column int, text string)
 }
 
 // NewTable returns a new table.
 func NewTable() *Table {
 	return &Table{
-		Box:          NewBox(),
-		bordersColor: Styles.GraphicsColor,
-		separator:    ' ',
-		lastColumn:   -1,
+		Box:            NewBox(),
+		bordersColor:   Styles.GraphicsColor,
+		separator:      ' ',
+		lastColumn:     -1,
+		minColumnWidth: 1,
 	}
 }
 
@@ -334,6 +403,32 @@ func (t *Table) SetBordersColor(color tcell.Color) *Table {
 	return t
 }
 
+// SetBordersStyle sets the style of the borders (see SetBorders()) or the
+// column separator (see SetSeparator()), including its background color and
+// attributes. This overrides the color set with SetBordersColor(). Provide
+// the empty struct (tcell.Style{}) to go back to using that color.
+func (t *Table) SetBordersStyle(style tcell.Style) *Table {
+	t.bordersStyle = style
+	return t
+}
+
+// SetBordersRunes sets the runes used to draw the borders (see SetBorders()),
+// e.g. to use ASCII characters for terminals which cannot display line
+// graphics:
+//
+//	table.SetBordersRunes(tview.TableBorders{
+//		Horizontal: '-', Vertical: '|',
+//		TopLeft: '+', TopRight: '+', BottomLeft: '+', BottomRight: '+',
+//		LeftT: '+', RightT: '+', TopT: '+', BottomT: '+', Cross: '+',
+//	})
+//
+// Runes set to 0 fall back to the corresponding runes in the global Borders
+// variable, which is also the default.
+func (t *Table) SetBordersRunes(runes TableBorders) *Table {
+	t.bordersRunes = runes
+	return t
+}
+
 // SetSelectedStyle sets a specific style for selected cells. If no such style
 // is set, per default, selected cells are inverted (i.e. their foreground and
 // background colors are swapped).
@@ -346,6 +441,20 @@ func (t *Table) SetSelectedStyle(style tcell.Style) *Table {
 	return t
 }
 
+// SetSelectedCellStyle sets a style for the current cell within the selected
+// row (if only rows are selectable) or the selected column (if only columns
+// are selectable), to emphasize it over the rest of the selection which is
+// styled according to SetSelectedStyle(). The current cell is the one at the
+// column (or row) most recently passed to Select(), e.g. by a mouse click.
+//
+// To reset a previous setting to its default, make the following call:
+//
+//	table.SetSelectedCellStyle(tcell.Style{})
+func (t *Table) SetSelectedCellStyle(style tcell.Style) *Table {
+	t.selectedCellStyle = style
+	return t
+}
+
 // SetSeparator sets the character used to fill the space between two
 // neighboring cells. This is a space character ' ' per default but you may
 // want to set it to Borders.Vertical (or any other rune) if the column
@@ -366,6 +475,23 @@ func (t *Table) SetFixed(rows, columns int) *Table {
 	return t
 }
 
+// SetFixedStyle sets a style for the cells in the fixed rows and columns (see
+// SetFixed()), e.g. to make header rows stand out without styling each of
+// their cells. The style's text color replaces the text color of cells which
+// have the default text color (Styles.PrimaryTextColor), its background color
+// is used for transparent cells, and its attributes are added to those of each
+// cell. Colors set with color tags or cell colors which differ from the
+// defaults take precedence. Provide tcell.ColorDefault as a color to leave it
+// unchanged.
+//
+// To reset a previous setting to its default, make the following call:
+//
+//	table.SetFixedStyle(tcell.Style{})
+func (t *Table) SetFixedStyle(style tcell.Style) *Table {
+	t.fixedStyle = style
+	return t
+}
+
 // SetSelectable sets the flags which determine what can be selected in a table.
 // There are three selection modi:
 //
@@ -421,6 +547,24 @@ func (t *Table) GetOffset() (row, column int) {
 	return t.rowOffset, t.columnOffset
 }
 
+// SetContentOffset sets the number of cells by which the text of the leftmost
+// non-fixed column is scrolled to the right. This allows reading columns which
+// are wider than the table itself. Fixed columns are never scrolled. The offset
+// is limited when the table is drawn such that the end of the column's widest
+// text remains visible, i.e. columns which fit are not scrolled. Users can
+// change the offset with Shift-Left and Shift-Right.
+func (t *Table) SetContentOffset(offset int) *Table {
+	t.contentOffset = offset
+	return t
+}
+
+// GetContentOffset returns the number of cells by which the text of the
+// leftmost non-fixed column is scrolled to the right, as limited during the
+// last call to Draw(). See SetContentOffset() for details.
+func (t *Table) GetContentOffset() int {
+	return t.contentOffset
+}
+
 // SetEvaluateAllRows sets a flag which determines the rows to be evaluated when
 // calculating the widths of the table's columns. When false, only visible rows
 // are evaluated. When true, all rows in the table are evaluated.
@@ -432,6 +576,72 @@ func (t *Table) SetEvaluateAllRows(all bool) *Table {
 	return t
 }
 
+// SetColumnWidth sets a fixed width (in screen cells) for the given column,
+// overriding the width calculated from the column's cell contents. The width is
+// clamped to the minimum column width (see SetMinColumnWidth()). Columns with a
+// fixed width do not expand (see TableCell.SetExpansion()). A width of 0 or
+// less removes the override.
+func (t *Table) SetColumnWidth(column, width int) *Table {
+	if width <= 0 {
+		delete(t.columnWidths, column)
+		return t
+	}
+	if width < t.minColumnWidth {
+		width = t.minColumnWidth
+	}
+	if t.columnWidths == nil {
+		t.columnWidths = make(map[int]int)
+	}
+	t.columnWidths[column] = width
+	return t
+}
+
+// GetColumnWidth returns the fixed width of the given column as set with
+// SetColumnWidth() or 0 if the column's width is calculated from its contents.
+func (t *Table) GetColumnWidth(column int) int {
+	return t.columnWidths[column]
+}
+
+// SetMinColumnWidth sets the minimum width a column can be given with
+// SetColumnWidth() or by resizing it with the keyboard. It defaults to 1.
+func (t *Table) SetMinColumnWidth(width int) *Table {
+	if width < 1 {
+		width = 1
+	}
+	t.minColumnWidth = width
+	return t
+}
+
+// SetColumnResizable sets whether or not the selected column can be resized
+// with the keyboard. If set to true and columns are selectable (see
+// SetSelectable()), the "+" and "-" keys widen and narrow the selected column
+// by one screen cell, respectively. The "=" key restores the column's
+// content-based width.
+func (t *Table) SetColumnResizable(resizable bool) *Table {
+	t.columnResizable = resizable
+	return t
+}
+
+// resizeColumn changes the width of the given column by the given delta,
+// starting at the width it had the last time the table was drawn if it has no
+// fixed width yet.
+func (t *Table) resizeColumn(column, delta int) {
+	width, ok := t.columnWidths[column]
+	if !ok {
+		for index, visibleColumn := range t.visibleColumnIndices {
+			if visibleColumn == column {
+				width = t.visibleColumnWidths[index]
+				break
+			}
+		}
+	}
+	width += delta
+	if width < t.minColumnWidth {
+		width = t.minColumnWidth
+	}
+	t.SetColumnWidth(column, width)
+}
+
 // SetSelectedFunc sets a handler which is called whenever the user presses the
 // Enter key on a selected cell/row/column. The handler receives the position of
 // the selection and its cell contents. If entire rows are selected, the column
@@ -450,6 +660,166 @@ func (t *Table) SetSelectionChangedFunc(handler func(row, column int)) *Table {
 	return t
 }
 
+// SetCellClickedFunc sets a handler which is called when the user clicks on a
+// cell with the mouse. It receives the position of the clicked cell and the
+// cell itself, e.g. to retrieve its reference (see TableCell.GetReference()).
+// It is called after the cell's own clicked handler (see
+// TableCell.SetClickedFunc()). If it returns true, the cell is not selected.
+func (t *Table) SetCellClickedFunc(handler func(row, column int, cell *TableCell) bool) *Table {
+	t.cellClicked = handler
+	return t
+}
+
+// SetCellSelectableFunc sets a function which decides whether the cell at the
+// given position can be selected by the user. This is useful for cells such as
+// spacers or computed totals whose selectability depends on the application's
+// data rather than on the cell itself. A cell is only selectable if this
+// function returns true and its NotSelectable flag is not set (see
+// TableCell.SetSelectable()). Keyboard navigation skips cells which are not
+// selectable in the direction of travel, and clicks on them do not change the
+// selection. Provide nil to make all cells selectable again.
+func (t *Table) SetCellSelectableFunc(handler func(row, column int) bool) *Table {
+	t.cellSelectable = handler
+	return t
+}
+
+// isSelectable returns whether the cell at the given position exists and can
+// be selected.
+func (t *Table) isSelectable(row, column int) bool {
+	if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
+		return false
+	}
+	cell := t.cells[row][column]
+	if cell == nil || cell.NotSelectable {
+		return false
+	}
+	return t.cellSelectable == nil || t.cellSelectable(row, column)
+}
+
+// SetEditable sets whether the user can edit the text of table cells. This
+// requires that both rows and columns are selectable (see SetSelectable()).
+// Pressing Enter on a selectable cell then opens an inline input field in
+// place of the cell instead of calling the handler set with
+// SetSelectedFunc(). The input field covers the cell and its text is aligned
+// like the cell's text.
+//
+// While editing, Enter, Tab, Backtab, and the up and down arrow keys store
+// the entered text in the cell and Escape discards it. Clicking outside the
+// input field also stores the text. The handler set with SetCellEditedFunc()
+// is called when text was stored.
+func (t *Table) SetEditable(editable bool) *Table {
+	t.editable = editable
+	if !editable {
+		t.editor = nil
+	}
+	return t
+}
+
+// SetCellEditedFunc sets a handler which is called when the user has finished
+// editing a cell (see SetEditable()), after the cell's text was updated. It
+// receives the cell's position and its new text.
+func (t *Table) SetCellEditedFunc(handler func(row, column int, newText string)) *Table {
+	t.cellEdited = handler
+	return t
+}
+
+// IsEditing returns whether the user is currently editing a cell.
+func (t *Table) IsEditing() bool {
+	return t.editor != nil
+}
+
+// startEditing opens an input field for the cell at the given position.
+func (t *Table) startEditing(row, column int) {
+	cell := t.cells[row][column]
+	t.editRow, t.editColumn = row, column
+	t.editor = NewInputField().
+		SetText(cell.Text).
+		SetFieldTextColor(Styles.PrimaryTextColor).
+		SetFieldBackgroundColor(Styles.ContrastBackgroundColor)
+	t.editor.SetBackgroundColor(Styles.ContrastBackgroundColor)
+	t.editor.SetDoneFunc(func(key tcell.Key) {
+		t.finishEditing(key != tcell.KeyEscape)
+	})
+	if t.HasFocus() {
+		t.editor.Focus(nil) // Show the cursor.
+	}
+}
+
+// finishEditing closes the input field, storing its text in the edited cell
+// if "commit" is true.
+func (t *Table) finishEditing(commit bool) {
+	editor := t.editor
+	if editor == nil {
+		return
+	}
+	t.editor = nil
+	if !commit || t.editRow >= len(t.cells) || t.editColumn >= len(t.cells[t.editRow]) {
+		return
+	}
+	cell := t.cells[t.editRow][t.editColumn]
+	if cell == nil {
+		return
+	}
+	cell.SetText(editor.GetText())
+	if t.cellEdited != nil {
+		t.cellEdited(t.editRow, t.editColumn, cell.Text)
+	}
+}
+
+// drawEditor draws the input field of the cell being edited, if any.
+func (t *Table) drawEditor(screen tcell.Screen) {
+	if t.editor == nil || t.editRow >= len(t.cells) || t.editColumn >= len(t.cells[t.editRow]) {
+		return
+	}
+	cell := t.cells[t.editRow][t.editColumn]
+	if cell == nil {
+		return
+	}
+	x, y, width := cell.GetLastPosition()
+	if width < 1 {
+		width = 1
+	}
+
+	// Align the text (plus the cursor behind it) like the cell's text by
+	// padding the input field.
+	var padding int
+	if textWidth := stringWidth(t.editor.GetText()) + 1; textWidth < width {
+		switch cell.Align {
+		case AlignRight:
+			padding = width - textWidth
+		case AlignCenter:
+			padding = (width - textWidth) / 2
+		}
+	}
+	t.editor.SetBorderPadding(0, 0, padding, 0)
+	t.editor.SetRect(x, y, width, 1)
+	t.editor.Draw(screen)
+}
+
+// Focus is called when this primitive receives focus.
+func (t *Table) Focus(delegate func(p Primitive)) {
+	t.Box.Focus(delegate)
+	if t.editor != nil {
+		t.editor.Focus(nil) // Show the cursor.
+	}
+}
+
+// Blur is called when this primitive loses focus.
+func (t *Table) Blur() {
+	if t.editor != nil {
+		t.editor.Blur()
+	}
+	t.Box.Blur()
+}
+
+// GetSelectedCell returns the cell at the current selection (see
+// GetSelection()). If entire rows or columns are selected, this is the cell at
+// the undefined coordinate given by GetSelection(). If there is no such cell,
+// an uninitialized cell is returned, as with GetCell().
+func (t *Table) GetSelectedCell() *TableCell {
+	return t.GetCell(t.selectedRow, t.selectedColumn)
+}
+
 // SetDoneFunc sets a handler which is called whenever the user presses the
 // Escape, Tab, or Backtab key. If nothing is selected, it is also called when
 // user presses the Enter key (because pressing Enter on a selection triggers
@@ -499,7 +869,7 @@ func (t *Table) SetCellSimple(row, column int, text string) *Table {
 // be inserted. Therefore, repeated calls to this function may return different
 // pointers for uninitialized cells.
 func (t *Table) GetCell(row, column int) *TableCell {
-	if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
+	if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) || t.cells[row][column] == nil {
 		return &TableCell{}
 	}
 	return t.cells[row][column]
@@ -639,6 +1009,7 @@ func (t *Table) ScrollToEnd() *Table {
 // Draw draws this primitive onto the screen.
 func (t *Table) Draw(screen tcell.Screen) {
 	t.Box.DrawForSubclass(screen, t)
+	defer t.drawEditor(screen) // Draw the editor last, after all selections.
 
 	// What's our available screen space?
 	_, totalHeight := screen.Size()
@@ -666,8 +1037,7 @@ func (t *Table) Draw(screen tcell.Screen) {
 			t.selectedRow = 0
 		}
 		for t.selectedRow < len(t.cells) {
-			cell := getCell(t.selectedRow, t.selectedColumn)
-			if cell != nil && !cell.NotSelectable {
+			if t.isSelectable(t.selectedRow, t.selectedColumn) {
 				break
 			}
 			t.selectedColumn++
@@ -822,6 +1192,10 @@ ColumnLoop:
 		if maxWidth < 0 {
 			break // No more cells found in this column.
 		}
+		if fixedWidth, ok := t.columnWidths[column]; ok {
+			maxWidth = fixedWidth // Manual widths take precedence.
+			expansion = 0
+		}
 
 		// Store new column info at the end.
 		columns = append(columns, column)
@@ -847,12 +1221,74 @@ ColumnLoop:
 		}
 	}
 
+	// Limit the content offset of the leftmost non-fixed column.
+	contentColumn := -1
+	if len(columns) > t.fixedColumns {
+		contentColumn = columns[t.fixedColumns]
+		available := widths[t.fixedColumns]
+		columnX := -1
+		if t.borders {
+			columnX = 0
+		}
+		for _, w := range widths[:t.fixedColumns] {
+			columnX += w + 1
+		}
+		if columnX+available >= width {
+			available = width - columnX - 1
+		}
+		var textWidth int
+		for _, row := range rows {
+			if cell := getCell(row, contentColumn); cell != nil {
+				if w := TaggedStringWidth(cell.Text); w > textWidth {
+					textWidth = w
+				}
+			}
+		}
+		if t.contentOffset > textWidth-available {
+			t.contentOffset = textWidth - available
+		}
+	}
+	if t.contentOffset < 0 {
+		t.contentOffset = 0
+	}
+
 	// Helper function which draws border runes.
 	borderStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.bordersColor)
+	if t.bordersStyle != (tcell.Style{}) {
+		borderStyle = t.bordersStyle
+	}
+	runes := t.bordersRunes
+	for _, r := range []struct {
+		custom   *rune
+		fallback rune
+	}{
+		{&runes.Horizontal, Borders.Horizontal},
+		{&runes.Vertical, Borders.Vertical},
+		{&runes.TopLeft, Borders.TopLeft},
+		{&runes.TopRight, Borders.TopRight},
+		{&runes.BottomLeft, Borders.BottomLeft},
+		{&runes.BottomRight, Borders.BottomRight},
+		{&runes.LeftT, Borders.LeftT},
+		{&runes.RightT, Borders.RightT},
+		{&runes.TopT, Borders.TopT},
+		{&runes.BottomT, Borders.BottomT},
+		{&runes.Cross, Borders.Cross},
+	} {
+		if *r.custom == 0 {
+			*r.custom = r.fallback
+		}
+	}
 	drawBorder := func(colX, rowY int, ch rune) {
 		screen.SetContent(x+colX, y+rowY, ch, nil, borderStyle)
 	}
 
+	// The style of fixed cells.
+	fixedFg, fixedBg, fixedAttr := t.fixedStyle.Decompose()
+	hasFixedStyle := t.fixedStyle != (tcell.Style{})
+	isFixed := func(row, column int) bool {
+		return hasFixedStyle && (row < t.fixedRows || column < t.fixedColumns)
+	}
+
 	// Draw the cells (and borders).
 	var columnX int
 	if !t.borders {
@@ -865,24 +1301,24 @@ ColumnLoop:
 				// Draw borders.
 				rowY *= 2
 				for pos := 0; pos < columnWidth && columnX+1+pos < width; pos++ {
-					drawBorder(columnX+pos+1, rowY, Borders.Horizontal)
+					drawBorder(columnX+pos+1, rowY, runes.Horizontal)
 				}
-				ch := Borders.Cross
+				ch := runes.Cross
 				if columnIndex == 0 {
 					if rowY == 0 {
-						ch = Borders.TopLeft
+						ch = runes.TopLeft
 					} else {
-						ch = Borders.LeftT
+						ch = runes.LeftT
 					}
 				} else if rowY == 0 {
-					ch = Borders.TopT
+					ch = runes.TopT
 				}
 				drawBorder(columnX, rowY, ch)
 				rowY++
 				if rowY >= height || y+rowY >= totalHeight {
 					break // No space for the text anymore.
 				}
-				drawBorder(columnX, rowY, Borders.Vertical)
+				drawBorder(columnX, rowY, runes.Vertical)
 			} else if columnIndex > 0 {
 				// Draw separator.
 				drawBorder(columnX, rowY, t.separator)
@@ -900,21 +1336,32 @@ ColumnLoop:
 				finalWidth = width - columnX - 1
 			}
 			cell.x, cell.y, cell.width = x+columnX+1, y+rowY, finalWidth
-			_, printed, _, _ := printWithStyle(screen, cell.Text, x+columnX+1, y+rowY, 0, finalWidth, cell.Align, tcell.StyleDefault.Foreground(cell.Color).Attributes(cell.Attributes), true)
-			if TaggedStringWidth(cell.Text)-printed > 0 && printed > 0 {
+			var skipWidth int
+			if column == contentColumn {
+				skipWidth = t.contentOffset
+			}
+			textStyle := tcell.StyleDefault.Foreground(cell.Color).Attributes(cell.Attributes)
+			if isFixed(row, column) {
+				if fixedFg != tcell.ColorDefault && cell.Color == Styles.PrimaryTextColor {
+					textStyle = textStyle.Foreground(fixedFg)
+				}
+				textStyle = textStyle.Attributes(cell.Attributes | fixedAttr)
+			}
+			_, printed, _, _ := printWithStyle(screen, cell.Text, x+columnX+1, y+rowY, skipWidth, finalWidth, cell.Align, textStyle, true)
+			if TaggedStringWidth(cell.Text)-skipWidth-printed > 0 && printed > 0 {
 				_, _, style, _ := screen.GetContent(x+columnX+finalWidth, y+rowY)
-				printWithStyle(screen, string(SemigraphicsHorizontalEllipsis), x+columnX+finalWidth, 0, y+rowY, 1, AlignLeft, style, false)
+				printWithStyle(screen, string(SemigraphicsHorizontalEllipsis), x+columnX+finalWidth, y+rowY, 0, 1, AlignLeft, style, false)
 			}
 		}
 
 		// Draw bottom border.
 		if rowY := 2 * len(rows); t.borders && rowY < height {
 			for pos := 0; pos < columnWidth && columnX+1+pos < width; pos++ {
-				drawBorder(columnX+pos+1, rowY, Borders.Horizontal)
+				drawBorder(columnX+pos+1, rowY, runes.Horizontal)
 			}
-			ch := Borders.BottomT
+			ch := runes.BottomT
 			if columnIndex == 0 {
-				ch = Borders.BottomLeft
+				ch = runes.BottomLeft
 			}
 			drawBorder(columnX, rowY, ch)
 		}
@@ -927,16 +1374,16 @@ ColumnLoop:
 		for rowY := range rows {
 			rowY *= 2
 			if rowY+1 < height {
-				drawBorder(columnX, rowY+1, Borders.Vertical)
+				drawBorder(columnX, rowY+1, runes.Vertical)
 			}
-			ch := Borders.RightT
+			ch := runes.RightT
 			if rowY == 0 {
-				ch = Borders.TopRight
+				ch = runes.TopRight
 			}
 			drawBorder(columnX, rowY, ch)
 		}
 		if rowY := 2 * len(rows); rowY < height {
-			drawBorder(columnX, rowY, Borders.BottomRight)
+			drawBorder(columnX, rowY, runes.BottomRight)
 		}
 	}
 
@@ -976,6 +1423,8 @@ ColumnLoop:
 		x, y, w, h int
 		cell       *TableCell
 		selected   bool
+		current    bool
+		fixed      bool
 	}
 	cellsByBackgroundColor := make(map[tcell.Color][]*cellInfo)
 	var backgroundColors []tcell.Color
@@ -995,7 +1444,8 @@ ColumnLoop:
 				bh = 3
 			}
 			columnSelected := t.columnsSelectable && !t.rowsSelectable && column == t.selectedColumn
-			cellSelected := !cell.NotSelectable && (columnSelected || rowSelected || t.rowsSelectable && t.columnsSelectable && column == t.selectedColumn && row == t.selectedRow)
+			cellSelected := t.isSelectable(row, column) && (columnSelected || rowSelected || t.rowsSelectable && t.columnsSelectable && column == t.selectedColumn && row == t.selectedRow)
+			cellCurrent := rowSelected && column == t.selectedColumn || columnSelected && row == t.selectedRow
 			entries, ok := cellsByBackgroundColor[cell.BackgroundColor]
 			cellsByBackgroundColor[cell.BackgroundColor] = append(entries, &cellInfo{
 				x:        bx,
@@ -1004,6 +1454,8 @@ ColumnLoop:
 				h:        bh,
 				cell:     cell,
 				selected: cellSelected,
+				current:  cellCurrent,
+				fixed:    isFixed(row, column),
 			})
 			if !ok {
 				backgroundColors = append(backgroundColors, cell.BackgroundColor)
@@ -1022,15 +1474,20 @@ ColumnLoop:
 		return li < lj
 	})
 	selFg, selBg, selAttr := t.selectedStyle.Decompose()
+	curFg, curBg, curAttr := t.selectedCellStyle.Decompose()
 	for _, bgColor := range backgroundColors {
 		entries := cellsByBackgroundColor[bgColor]
 		for _, info := range entries {
 			if info.selected {
-				if t.selectedStyle != (tcell.Style{}) {
+				if info.current && t.selectedCellStyle != (tcell.Style{}) {
+					defer colorBackground(info.x, info.y, info.w, info.h, curBg, curFg, false, false, curAttr, false)
+				} else if t.selectedStyle != (tcell.Style{}) {
 					defer colorBackground(info.x, info.y, info.w, info.h, selBg, selFg, false, false, selAttr, false)
 				} else {
 					defer colorBackground(info.x, info.y, info.w, info.h, bgColor, info.cell.Color, false, false, 0, true)
 				}
+			} else if info.fixed && info.cell.Transparent && fixedBg != tcell.ColorDefault {
+				colorBackground(info.x, info.y, info.w, info.h, fixedBg, info.cell.Color, false, true, 0, false)
 			} else {
 				colorBackground(info.x, info.y, info.w, info.h, bgColor, info.cell.Color, info.cell.Transparent, true, 0, false)
 			}
@@ -1046,6 +1503,12 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		key := event.Key()
 
+		// Forward keys to the cell editor.
+		if t.editor != nil {
+			t.editor.InputHandler()(event, setFocus)
+			return
+		}
+
 		if (!t.rowsSelectable && !t.columnsSelectable && key == tcell.KeyEnter) ||
 			key == tcell.KeyEscape ||
 			key == tcell.KeyTab ||
@@ -1059,17 +1522,9 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 		// Movement functions.
 		previouslySelectedRow, previouslySelectedColumn := t.selectedRow, t.selectedColumn
 		var (
-			getCell = func(row, column int) *TableCell {
-				if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
-					return nil
-				}
-				return t.cells[row][column]
-			}
-
 			previous = func() {
 				for t.selectedRow >= 0 {
-					cell := getCell(t.selectedRow, t.selectedColumn)
-					if cell != nil && !cell.NotSelectable {
+					if t.isSelectable(t.selectedRow, t.selectedColumn) {
 						return
 					}
 					t.selectedColumn--
@@ -1089,8 +1544,7 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 					}
 				}
 				for t.selectedRow < len(t.cells) {
-					cell := getCell(t.selectedRow, t.selectedColumn)
-					if cell != nil && !cell.NotSelectable {
+					if t.isSelectable(t.selectedRow, t.selectedColumn) {
 						return
 					}
 					t.selectedColumn++
@@ -1128,7 +1582,15 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 			}
 
 			down = func() {
-				if t.rowsSelectable {
+				if t.rowsSelectable && t.columnsSelectable {
+					// Move to the next selectable cell in this column.
+					for row := t.selectedRow + 1; row < len(t.cells); row++ {
+						if t.isSelectable(row, t.selectedColumn) {
+							t.selectedRow = row
+							break
+						}
+					}
+				} else if t.rowsSelectable {
 					t.selectedRow++
 					if t.selectedRow >= len(t.cells) {
 						t.selectedRow = len(t.cells) - 1
@@ -1140,7 +1602,15 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 			}
 
 			up = func() {
-				if t.rowsSelectable {
+				if t.rowsSelectable && t.columnsSelectable {
+					// Move to the previous selectable cell in this column.
+					for row := t.selectedRow - 1; row >= 0; row-- {
+						if t.isSelectable(row, t.selectedColumn) {
+							t.selectedRow = row
+							break
+						}
+					}
+				} else if t.rowsSelectable {
 					t.selectedRow--
 					if t.selectedRow < 0 {
 						t.selectedRow = 0
@@ -1227,6 +1697,17 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 				left()
 			case 'l':
 				right()
+			case '+', '-', '=':
+				if t.columnResizable && t.columnsSelectable {
+					switch event.Rune() {
+					case '+':
+						t.resizeColumn(t.selectedColumn, 1)
+					case '-':
+						t.resizeColumn(t.selectedColumn, -1)
+					case '=':
+						t.SetColumnWidth(t.selectedColumn, 0)
+					}
+				}
 			}
 		case tcell.KeyHome:
 			home()
@@ -1237,15 +1718,27 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 		case tcell.KeyDown:
 			down()
 		case tcell.KeyLeft:
-			left()
+			if event.Modifiers()&tcell.ModShift != 0 {
+				t.contentOffset--
+			} else {
+				left()
+			}
 		case tcell.KeyRight:
-			right()
+			if event.Modifiers()&tcell.ModShift != 0 {
+				t.contentOffset++
+			} else {
+				right()
+			}
 		case tcell.KeyPgDn, tcell.KeyCtrlF:
 			pageDown()
 		case tcell.KeyPgUp, tcell.KeyCtrlB:
 			pageUp()
 		case tcell.KeyEnter:
-			if (t.rowsSelectable || t.columnsSelectable) && t.selected != nil {
+			if t.editable && t.rowsSelectable && t.columnsSelectable {
+				if t.isSelectable(t.selectedRow, t.selectedColumn) {
+					t.startEditing(t.selectedRow, t.selectedColumn)
+				}
+			} else if (t.rowsSelectable || t.columnsSelectable) && t.selected != nil {
 				t.selected(t.selectedRow, t.selectedColumn)
 			}
 		}
@@ -1267,6 +1760,16 @@ func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			return false, nil
 		}
 
+		// Clicks in the cell editor go to the editor, others end editing.
+		if t.editor != nil {
+			if t.editor.InRect(x, y) {
+				return t.editor.MouseHandler()(action, event, func(p Primitive) { setFocus(t) })
+			}
+			if action == MouseLeftDown || action == MouseLeftClick {
+				t.finishEditing(true)
+			}
+		}
+
 		switch action {
 		case MouseLeftClick:
 			selectEvent := true
@@ -1280,8 +1783,16 @@ func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 							selectEvent = false
 						}
 					}
+					if cell != nil && t.cellClicked != nil {
+						if noSelect := t.cellClicked(row, column, cell); noSelect {
+							selectEvent = false
+						}
+					}
 				}
 			}
+			if t.rowsSelectable && t.columnsSelectable && !t.isSelectable(row, column) {
+				selectEvent = false
+			}
 			if selectEvent && (t.rowsSelectable || t.columnsSelectable) {
 				t.Select(row, column)
 			}
@@ -3,6 +3,7 @@ package tview
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"regexp"
 	"sync"
 	"unicode/utf8"
@@ -17,6 +18,10 @@ var (
 	openColorRegex  = regexp.MustCompile(`\[([a-zA-Z]*|#[0-9a-zA-Z]*)$`)
 	openRegionRegex = regexp.MustCompile(`\["[a-zA-Z0-9_,;: \-\.]*"?$`)
 
+	// The leading whitespace and list bullet of a line which determine the
+	// hanging indent in HangingIndentAuto mode.
+	hangingIndentPattern = regexp.MustCompile(`^[ \t]*(?:(?:[-*+•◦‣]|[0-9]+[.)])[ \t]+)?`)
+
 	// TabSize is the number of spaces with which a tab character will be replaced.
 	TabSize = 4
 )
@@ -27,12 +32,20 @@ type textViewIndex struct {
 	Pos             int    // The index into the "buffer" string (byte position).
 	NextPos         int    // The (byte) index of the next line start within this buffer string.
 	Width           int    // The screen width of this line.
+	Indent          int    // The screen width of the space before this line (for wrapped lines).
 	ForegroundColor string // The starting foreground color ("" = don't change, "-" = reset).
 	BackgroundColor string // The starting background color ("" = don't change, "-" = reset).
 	Attributes      string // The starting attributes ("" = don't change, "-" = reset).
 	Region          string // The starting region ID.
 }
 
+// textViewLineTags caches the tags found in a buffer line while drawing.
+type textViewLineTags struct {
+	line                                          int // The index into the "buffer" slice, -1 if nothing was cached.
+	colorTagIndices, regionIndices, escapeIndices [][]int
+	colorTags, regions                            [][]string
+}
+
 // textViewRegion contains information about a region.
 type textViewRegion struct {
 	// The region ID.
@@ -170,6 +183,14 @@ type TextView struct {
 	// after punctuation characters.
 	wordWrap bool
 
+	// The indent of wrapped continuation lines, in screen cells, or
+	// HangingIndentAuto.
+	hangingIndent int
+
+	// The width at which lines are wrapped, if narrower than the available
+	// width. Ignored if 0.
+	wrapWidth int
+
 	// The (starting) color of the text.
 	textColor tcell.Color
 
@@ -192,6 +213,12 @@ type TextView struct {
 	// changed.
 	changed func()
 
+	// An optional function which receives lines discarded due to maxLines.
+	trimmed func(lines []string)
+
+	// An optional function which returns the gutter marker for a line.
+	gutter func(line int) (text string, style tcell.Style)
+
 	// An optional function which is called when the user presses one of the
 	// following keys: Escape, Enter, Tab, Backtab.
 	done func(tcell.Key)
@@ -200,11 +227,26 @@ type TextView struct {
 	// highlighted.
 	highlighted func(added, removed, remaining []string)
 
+	// An optional function which is called when a region was clicked.
+	regionClicked func(regionID string)
+
 	// cursorIndex tracks cursor position.
 	cursorIndex int
 
 	// showCursor tracks visual cursor.
 	showCursor bool
+
+	// The scroll bar visibility, one of the ScrollBar constants.
+	scrollBarVisibility int
+
+	// The colors of the scroll bar's track and thumb.
+	scrollBarColor, scrollBarThumbColor tcell.Color
+
+	// Whether the scroll bar was shown the last time the text view was drawn.
+	scrollBarShown bool
+
+	// Whether the user is currently dragging the scroll bar's thumb.
+	scrollBarDragging bool
 }
 
 // NewTextView returns a new text view.
@@ -220,6 +262,9 @@ func NewTextView() *TextView {
 		textColor:     Styles.PrimaryTextColor,
 		regions:       false,
 		dynamicColors: false,
+
+		scrollBarColor:      Styles.ContrastBackgroundColor,
+		scrollBarThumbColor: Styles.SecondaryTextColor,
 	}
 }
 
@@ -273,6 +318,70 @@ func (t *TextView) SetWordWrap(wrapOnWords bool) *TextView {
 	return t
 }
 
+// HangingIndentAuto may be provided to SetHangingIndent() to indent wrapped
+// lines by the width of the leading whitespace and list bullet of their text
+// line.
+const HangingIndentAuto = -1
+
+// SetHangingIndent sets the number of screen cells by which the continuation
+// lines of wrapped text lines are indented, e.g. to align them with the text
+// of a bulleted or numbered list item. The first line of each text line is not
+// affected. The default of 0 starts continuation lines at the left edge.
+//
+// If HangingIndentAuto is provided, the indent is determined for each text
+// line from its leading whitespace and an optional list bullet ("-", "*",
+// "+", "•", "◦", "‣", or a number followed by "." or ")") followed by
+// whitespace. For example, the continuation lines of "  - Buy milk" are
+// indented by four cells.
+//
+// The indent is limited to half of the available width. It only applies to
+// left-aligned text and is ignored if the "wrap" flag is false (see SetWrap()).
+// With a gutter (see SetGutterFunc()), lines are indented relative to the text
+// area to the right of the gutter.
+func (t *TextView) SetHangingIndent(indent int) *TextView {
+	if t.hangingIndent != indent {
+		t.index = nil
+	}
+	t.hangingIndent = indent
+	return t
+}
+
+// SetWrapWidth sets the number of screen cells after which lines are wrapped,
+// e.g. 72 for the text of an email, regardless of the text view's width. The
+// wrapped text is then placed within the available space according to the
+// text alignment (see SetTextAlign()): left-aligned text at the left edge,
+// right-aligned text at the right edge, and centered text in the middle.
+// Scrolling and the number of lines reported by the text view refer to the
+// wrapped text.
+//
+// If the available width is smaller, it is used instead. The default of 0
+// wraps lines at the available width. This value is ignored if the "wrap"
+// flag is false (see SetWrap()).
+func (t *TextView) SetWrapWidth(width int) *TextView {
+	if width < 0 {
+		width = 0
+	}
+	if t.wrapWidth != width {
+		t.index = nil
+	}
+	t.wrapWidth = width
+	return t
+}
+
+// GetWrapWidth returns the width set with SetWrapWidth().
+func (t *TextView) GetWrapWidth() int {
+	return t.wrapWidth
+}
+
+// textWidth returns the width of the text area for the given available width,
+// taking the wrap width into account.
+func (t *TextView) textWidth(width int) int {
+	if t.wrap && t.wrapWidth > 0 && t.wrapWidth < width {
+		return t.wrapWidth
+	}
+	return width
+}
+
 // SetMaxLines sets the maximum number of lines for this text view. Lines at the
 // beginning of the text will be discarded when the text view is drawn, so as to
 // remain below this value. Broken lines via word wrapping are counted
@@ -287,6 +396,55 @@ func (t *TextView) SetMaxLines(maxLines int) *TextView {
 	return t
 }
 
+// SetTrimmedFunc sets a handler which is called with the lines which are
+// discarded from the beginning of the text because of the limit set with
+// SetMaxLines(), oldest first. This can be used to archive old content, e.g.
+// by writing it to a file. The lines are provided as they were written,
+// including any color or region tags, but without line breaks. When a line
+// that was broken by word wrapping is only partially discarded, the discarded
+// part is provided.
+//
+// The handler is called while the text view is locked (during Write() or
+// Draw()) so it must not call any of the text view's methods.
+func (t *TextView) SetTrimmedFunc(handler func(lines []string)) *TextView {
+	t.trimmed = handler
+	return t
+}
+
+// SetGutterFunc sets a function which returns a marker to be displayed in a
+// gutter to the left of the text, e.g. a line number, a breakpoint symbol, or
+// a diff indicator. The function receives the index of a line of the text
+// (starting at 0, counting line breaks, not word-wrapped lines) and returns the
+// marker text, which may contain color tags, and its style. The marker is
+// right-aligned in the gutter and shown next to the first row of the line.
+// Rows continuing a word-wrapped line have an empty gutter.
+//
+// The gutter is as wide as the widest marker of all lines and the text area is
+// reduced accordingly. Include any desired spacing in the markers themselves.
+// The function is called for every line on every redraw, and while the text
+// view is locked, so it should be fast and must not call the text view's
+// methods. Provide nil to remove the gutter.
+func (t *TextView) SetGutterFunc(handler func(line int) (text string, style tcell.Style)) *TextView {
+	t.gutter = handler
+	return t
+}
+
+// SetScrollBarVisibility sets when a vertical scroll bar is shown on the right
+// edge of the text view, one of ScrollBarNever (the default), ScrollBarAuto
+// (only when the text does not fit), or ScrollBarAlways. While the scroll bar
+// is shown, the text area is one column narrower.
+func (t *TextView) SetScrollBarVisibility(visibility int) *TextView {
+	t.scrollBarVisibility = visibility
+	return t
+}
+
+// SetScrollBarColors sets the colors of the scroll bar's track and thumb.
+func (t *TextView) SetScrollBarColors(track, thumb tcell.Color) *TextView {
+	t.scrollBarColor = track
+	t.scrollBarThumbColor = thumb
+	return t
+}
+
 // SetTextAlign sets the text alignment within the text view. This must be
 // either AlignLeft, AlignCenter, or AlignRight.
 func (t *TextView) SetTextAlign(align int) *TextView {
@@ -306,15 +464,37 @@ func (t *TextView) SetTextColor(color tcell.Color) *TextView {
 }
 
 // SetText sets the text of this text view to the provided string. Previously
-// contained text will be removed.
+// contained text will be removed. Highlighted regions remain highlighted if the
+// new text defines regions with the same IDs (see Highlight()).
+//
+// Use SetTextKeepScroll() to replace the text while explicitly keeping the
+// scroll position, or call ScrollToBeginning() afterwards to reset it.
 func (t *TextView) SetText(text string) *TextView {
 	t.Clear()
 	fmt.Fprint(t, text)
 	return t
 }
 
+// SetTextKeepScroll works like SetText() but keeps the current scroll position
+// (including tracking of the end of the text, see ScrollToEnd()), even if a
+// scroll to the highlighted regions is pending (see ScrollToHighlight()). This
+// is useful when refreshing live content which changes only slightly. The
+// offsets are clamped to the new text when the text view is drawn the next
+// time. Highlighted regions are kept as with SetText().
+func (t *TextView) SetTextKeepScroll(text string) *TextView {
+	lineOffset, columnOffset, trackEnd := t.lineOffset, t.columnOffset, t.trackEnd
+	t.Clear()
+	t.regionInfos = nil
+	fmt.Fprint(t, text)
+	t.lineOffset, t.columnOffset, t.trackEnd = lineOffset, columnOffset, trackEnd
+	t.scrollToHighlights = false
+	return t
+}
+
 // GetText returns the current text of this text view. If "stripAllTags" is set
-// to true, any region/color tags are stripped from the text.
+// to true, any region/color tags are stripped from the text and escaped tags
+// are unescaped, i.e. the text is returned as it is displayed. Only the tags
+// enabled with SetDynamicColors() and SetRegions() are affected.
 func (t *TextView) GetText(stripAllTags bool) string {
 	// Get the buffer.
 	buffer := t.buffer
@@ -339,7 +519,7 @@ func (t *TextView) GetText(stripAllTags bool) string {
 				return match
 			})
 		}
-		if t.regions && !t.dynamicColors {
+		if t.regions || t.dynamicColors {
 			text = escapePattern.ReplaceAll(text, []byte(`[$1$2]`))
 		}
 	}
@@ -349,6 +529,10 @@ func (t *TextView) GetText(stripAllTags bool) string {
 
 // SetDynamicColors sets the flag that allows the text color to be changed
 // dynamically. See class description for details.
+//
+// If neither dynamic colors nor regions (see SetRegions()) are enabled, the
+// text is displayed verbatim, i.e. square brackets, tag-like strings, and
+// escaped tags such as "[red[]" are printed exactly as they were written.
 func (t *TextView) SetDynamicColors(dynamic bool) *TextView {
 	if t.dynamicColors != dynamic {
 		t.index = nil
@@ -408,6 +592,16 @@ func (t *TextView) SetHighlightedFunc(handler func(added, removed, remaining []s
 	return t
 }
 
+// SetRegionClickedFunc sets a handler which is called when the user clicks on
+// the text of a region with the mouse (regions must be enabled, see
+// SetRegions()). The handler receives the ID of the clicked region. This is
+// called after the region was highlighted (see SetHighlightedFunc()). It can
+// be used to implement links within the text.
+func (t *TextView) SetRegionClickedFunc(handler func(regionID string)) *TextView {
+	t.regionClicked = handler
+	return t
+}
+
 // ScrollTo scrolls to the specified row and column (both starting with 0).
 func (t *TextView) ScrollTo(row, column int) *TextView {
 	if !t.scrollable {
@@ -490,6 +684,58 @@ func (t *TextView) Highlight(regionIDs ...string) *TextView {
 		regionIDs = newIDs
 	} // Now we have a list of region IDs that end up being highlighted.
 
+	t.setHighlights(regionIDs)
+	return t
+}
+
+// AddHighlights highlights the given regions in addition to the regions which
+// are already highlighted, regardless of the toggle setting (see
+// SetToggleHighlights()). This is useful to select multiple regions one after
+// another.
+func (t *TextView) AddHighlights(regionIDs ...string) *TextView {
+	newIDs := t.GetHighlights()
+	for _, regionID := range regionIDs {
+		if _, ok := t.highlights[regionID]; !ok && regionID != "" {
+			newIDs = append(newIDs, regionID)
+		}
+	}
+	t.setHighlights(newIDs)
+	return t
+}
+
+// RemoveHighlights removes the highlight from the given regions. Other
+// highlighted regions remain highlighted.
+func (t *TextView) RemoveHighlights(regionIDs ...string) *TextView {
+	var newIDs []string
+	for _, id := range t.GetHighlights() {
+		var found bool
+		for _, regionID := range regionIDs {
+			if id == regionID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			newIDs = append(newIDs, id)
+		}
+	}
+	t.setHighlights(newIDs)
+	return t
+}
+
+// ToggleHighlight highlights the given region if it is not highlighted and
+// removes its highlight otherwise. Other highlighted regions are not affected,
+// regardless of the toggle setting (see SetToggleHighlights()).
+func (t *TextView) ToggleHighlight(regionID string) *TextView {
+	if _, ok := t.highlights[regionID]; ok {
+		return t.RemoveHighlights(regionID)
+	}
+	return t.AddHighlights(regionID)
+}
+
+// setHighlights replaces the highlighted regions with the given ones and
+// notifies the "highlighted" handler of any changes.
+func (t *TextView) setHighlights(regionIDs []string) {
 	// Determine added and removed regions.
 	var added, removed, remaining []string
 	if t.highlighted != nil {
@@ -517,14 +763,13 @@ func (t *TextView) Highlight(regionIDs ...string) *TextView {
 	t.index = nil
 
 	// Notify.
-	if t.highlighted != nil && len(added) > 0 || len(removed) > 0 {
+	if t.highlighted != nil && (len(added) > 0 || len(removed) > 0) {
 		t.highlighted(added, removed, remaining)
 	}
-
-	return t
 }
 
-// GetHighlights returns the IDs of all currently highlighted regions.
+// GetHighlights returns the IDs of all currently highlighted regions, in no
+// particular order.
 func (t *TextView) GetHighlights() (regionIDs []string) {
 	for id := range t.highlights {
 		regionIDs = append(regionIDs, id)
@@ -572,65 +817,35 @@ func (t *TextView) GetRegionText(regionID string) string {
 
 	var (
 		buffer          bytes.Buffer
-		currentRegionID []byte
+		currentRegionID string
 	)
 
 	for _, str := range t.buffer {
-		// Find all color tags in this line.
-		var colorTagIndices [][]int
-		if t.dynamicColors {
-			colorTagIndices = colorPattern.FindAllIndex(str, -1)
-		}
-
-		// Find all regions in this line.
-		var (
-			regionIndices [][]int
-			regions       [][][]byte
-		)
-		if t.regions {
-			regionIndices = regionPattern.FindAllIndex(str, -1)
-			regions = regionPattern.FindAllSubmatch(str, -1)
-		}
-
-		// Analyze this line.
-		var currentTag, currentRegion int
-		for pos, ch := range str {
-			// Skip any color tags.
-			if currentTag < len(colorTagIndices) && pos >= colorTagIndices[currentTag][0] && pos < colorTagIndices[currentTag][1] {
-				if pos == colorTagIndices[currentTag][1]-1 {
-					currentTag++
-				}
-				if colorTagIndices[currentTag][1]-colorTagIndices[currentTag][0] > 2 {
-					continue
-				}
+		// Go through the segments of this line between region tags.
+		line := string(str)
+		var from int
+		for _, region := range regionPattern.FindAllStringSubmatchIndex(line, -1) {
+			if currentRegionID == regionID {
+				// Remove color tags and escapes from the region's text. (There
+				// are no region tags in this segment.)
+				_, _, _, _, _, stripped, _ := decomposeString(line[from:region[0]], t.dynamicColors, true)
+				buffer.WriteString(stripped)
 			}
-
-			// Skip any regions.
-			if currentRegion < len(regionIndices) && pos >= regionIndices[currentRegion][0] && pos < regionIndices[currentRegion][1] {
-				if pos == regionIndices[currentRegion][1]-1 {
-					if string(currentRegionID) == regionID {
-						// This is the end of the requested region. We're done.
-						return buffer.String()
-					}
-					currentRegionID = regions[currentRegion][1]
-					currentRegion++
-				}
-				continue
-			}
-
-			// Add this rune.
-			if string(currentRegionID) == regionID {
-				buffer.WriteRune(rune(ch))
+			if currentRegionID == regionID && line[region[2]:region[3]] != regionID {
+				// This is the end of the requested region. We're done.
+				return buffer.String()
 			}
+			currentRegionID = line[region[2]:region[3]]
+			from = region[1]
 		}
-
-		// Add newline.
-		if string(currentRegionID) == regionID {
+		if currentRegionID == regionID {
+			_, _, _, _, _, stripped, _ := decomposeString(line[from:], t.dynamicColors, true)
+			buffer.WriteString(stripped)
 			buffer.WriteRune('\n')
 		}
 	}
 
-	return escapePattern.ReplaceAllString(buffer.String(), `[$1$2]`)
+	return buffer.String()
 }
 
 // Focus is called when this primitive receives focus.
@@ -674,6 +889,49 @@ func (t *TextView) Write(bb []byte) (n int, err error) {
 	t.Lock()
 	defer t.Unlock()
 
+	return t.write(bb)
+}
+
+// BatchWrite calls the provided function with a writer which appends to the
+// text view like Write() does. The text view is locked for the duration of the
+// call and the "changed" handler (see SetChangedFunc()) is called only once
+// at the end, instead of once per write. The text is re-indexed when it is
+// drawn the next time, so scrolling to the end (if the text view is at the end)
+// happens once for the entire batch. This is useful to add many small chunks,
+// e.g. from a high-throughput log stream.
+//
+// The provided function must not call any other methods of the text view.
+func (t *TextView) BatchWrite(f func(w io.Writer)) *TextView {
+	// Notify at the end, after unlocking.
+	t.Lock()
+	changed := t.changed
+	t.Unlock()
+	if changed != nil {
+		defer func() {
+			go changed()
+		}()
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	f(textViewBatchWriter{t})
+	return t
+}
+
+// textViewBatchWriter writes into a locked text view. See
+// TextView.BatchWrite().
+type textViewBatchWriter struct {
+	t *TextView
+}
+
+// Write implements io.Writer.
+func (w textViewBatchWriter) Write(bb []byte) (int, error) {
+	return w.t.write(bb)
+}
+
+// write appends the given bytes to the buffer. The text view must be locked.
+func (t *TextView) write(bb []byte) (n int, err error) {
 	// Copy data over.
 	var newBytes []byte
 	if t.recentBytes == nil {
@@ -713,12 +971,16 @@ func (t *TextView) Write(bb []byte) (n int, err error) {
 	} else {
 		t.buffer = append(t.buffer, make([]byte, 0, defaultLineSize))
 	}
+	var trimmed []string
 	for i := 0; i < len(newBytes); i++ {
 		b := newBytes[i]
 		switch b {
 		case '\n':
 			if t.maxLines > 0 && len(t.buffer) > t.maxLines {
 				line := t.buffer[0]
+				if t.trimmed != nil {
+					trimmed = append(trimmed, string(line))
+				}
 				t.buffer = t.buffer[1:]
 				t.buffer = append(t.buffer, line[:0])
 			} else {
@@ -732,6 +994,10 @@ func (t *TextView) Write(bb []byte) (n int, err error) {
 		}
 	}
 
+	if len(trimmed) > 0 {
+		t.trimmed(trimmed)
+	}
+
 	// Reset the index.
 	t.index = nil
 
@@ -769,12 +1035,31 @@ func (t *TextView) reindexBuffer(width int) {
 		line := string(bline)
 		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedStr, _ := decomposeString(line, t.dynamicColors, t.regions)
 
+		// Determine the indent of continuation lines.
+		var indent int
+		if t.wrap && t.align == AlignLeft {
+			indent = t.hangingIndent
+			if indent == HangingIndentAuto {
+				indent = stringWidth(hangingIndentPattern.FindString(strippedStr))
+			}
+			if indent > width/2 {
+				indent = width / 2
+			}
+			if indent < 0 {
+				indent = 0
+			}
+		}
+
 		// Split the line if required.
 		var splitLines []string
 		// str = []byte(strippedStr)
 		if t.wrap && len(strippedStr) > 0 {
 			for len(strippedStr) > 0 {
-				extract := runewidth.Truncate(strippedStr, width, "")
+				lineWidth := width
+				if len(splitLines) > 0 {
+					lineWidth -= indent
+				}
+				extract := runewidth.Truncate(strippedStr, lineWidth, "")
 				if len(extract) == 0 {
 					// We'll extract at least one grapheme cluster.
 					gr := uniseg.NewGraphemes(strippedStr)
@@ -805,7 +1090,7 @@ func (t *TextView) reindexBuffer(width int) {
 
 		// Create index from split lines.
 		var originalPos, colorPos, regionPos, escapePos int
-		for _, splitLine := range splitLines {
+		for splitIndex, splitLine := range splitLines {
 			line := &textViewIndex{
 				Line:            bufferIndex,
 				Pos:             originalPos,
@@ -830,7 +1115,8 @@ func (t *TextView) reindexBuffer(width int) {
 					nextTag = append(nextTag, [3]int{regionIndices[regionPos][0], regionIndices[regionPos][1], 1}) // 1 = region tag.
 				}
 				if escapePos < len(escapeIndices) {
-					nextTag = append(nextTag, [3]int{escapeIndices[escapePos][0], escapeIndices[escapePos][1], 2}) // 2 = escape tag.
+					// Only the removed bracket counts, the rest is regular text.
+					nextTag = append(nextTag, [3]int{escapeIndices[escapePos][1] - 2, escapeIndices[escapePos][1] - 1, 2}) // 2 = escape tag.
 				}
 				minPos := -1
 				tagIndex := -1
@@ -850,9 +1136,6 @@ func (t *TextView) reindexBuffer(width int) {
 				strippedTagStart := nextTag[tagIndex][0] - originalPos - totalTagLength
 				tagEnd = nextTag[tagIndex][1]
 				tagLength := tagEnd - nextTag[tagIndex][0]
-				if nextTag[tagIndex][2] == 2 {
-					tagLength = 1
-				}
 				totalTagLength += tagLength
 				remainingLength = lineLength - (tagEnd - originalPos - totalTagLength)
 
@@ -891,6 +1174,9 @@ func (t *TextView) reindexBuffer(width int) {
 			// Append this line.
 			line.NextPos = originalPos
 			line.Width = stringWidth(splitLine)
+			if splitIndex > 0 {
+				line.Indent = indent
+			}
 			t.index = append(t.index, line)
 		}
 
@@ -931,6 +1217,18 @@ func (t *TextView) reindexBuffer(width int) {
 			line.Line -= bufferShift
 		}
 
+		// Report the discarded text.
+		if t.trimmed != nil {
+			trimmed := make([]string, 0, bufferShift+1)
+			for _, line := range t.buffer[:bufferShift] {
+				trimmed = append(trimmed, string(line))
+			}
+			if pos := t.index[0].Pos; pos > 0 {
+				trimmed = append(trimmed, string(t.buffer[bufferShift][:pos]))
+			}
+			t.trimmed(trimmed)
+		}
+
 		// Adjust the original buffer.
 		t.buffer = t.buffer[bufferShift:]
 		var prefix string
@@ -961,12 +1259,62 @@ func (t *TextView) reindexBuffer(width int) {
 	// Calculate longest line.
 	t.longestLine = 0
 	for _, line := range t.index {
-		if line.Width > t.longestLine {
-			t.longestLine = line.Width
+		if line.Indent+line.Width > t.longestLine {
+			t.longestLine = line.Indent + line.Width
 		}
 	}
 }
 
+// decomposeIndexLine returns the tags of the given index line, with positions
+// relative to the start of the line, and the line's text without the tags.
+// The tags are taken from the entire buffer line so that tags which straddle
+// a wrap boundary, such as escaped brackets, are handled like in unwrapped
+// text. The tags of the last buffer line are kept in "cache".
+func (t *TextView) decomposeIndexLine(index *textViewIndex, cache *textViewLineTags) (colorTagIndices [][]int, colorTags [][]string, regionIndices [][]int, regions [][]string, escapeIndices [][]int, stripped string) {
+	if cache.line != index.Line {
+		cache.colorTagIndices, cache.colorTags, cache.regionIndices, cache.regions, cache.escapeIndices, _, _ = decomposeString(string(t.buffer[index.Line]), t.dynamicColors, t.regions)
+		cache.line = index.Line
+	}
+	from, to := index.Pos, index.NextPos
+	text := t.buffer[index.Line][from:to]
+
+	// Collect the tags of this line and mark the bytes to be removed.
+	remove := make([]bool, len(text))
+	for tagIndex, tag := range cache.colorTagIndices {
+		if tag[0] >= from && tag[0] < to {
+			colorTagIndices = append(colorTagIndices, []int{tag[0] - from, tag[1] - from})
+			colorTags = append(colorTags, cache.colorTags[tagIndex])
+			for pos := tag[0]; pos < tag[1] && pos < to; pos++ {
+				remove[pos-from] = true
+			}
+		}
+	}
+	for tagIndex, tag := range cache.regionIndices {
+		if tag[0] >= from && tag[0] < to {
+			regionIndices = append(regionIndices, []int{tag[0] - from, tag[1] - from})
+			regions = append(regions, cache.regions[tagIndex])
+			for pos := tag[0]; pos < tag[1] && pos < to; pos++ {
+				remove[pos-from] = true
+			}
+		}
+	}
+	for _, tag := range cache.escapeIndices {
+		if bracket := tag[1] - 2; bracket >= from && bracket < to {
+			escapeIndices = append(escapeIndices, []int{tag[0] - from, tag[1] - from})
+			remove[bracket-from] = true
+		}
+	}
+
+	// Remove the tags.
+	buf := make([]byte, 0, len(text))
+	for pos, ch := range text {
+		if !remove[pos] {
+			buf = append(buf, ch)
+		}
+	}
+	return colorTagIndices, colorTags, regionIndices, regions, escapeIndices, string(buf)
+}
+
 // Draw draws this primitive onto the screen.
 func (t *TextView) Draw(screen tcell.Screen) {
 	t.Box.DrawForSubclass(screen, t)
@@ -978,6 +1326,64 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	x, y, width, height := t.GetInnerRect()
 	t.pageSize = height
 
+	// Determine the gutter markers and the gutter's width.
+	type gutterMarker struct {
+		text  string
+		style tcell.Style
+	}
+	var (
+		gutterMarkers []gutterMarker
+		gutterWidth   int
+		gutterX       = x
+	)
+	if t.gutter != nil {
+		gutterMarkers = make([]gutterMarker, len(t.buffer))
+		for line := range t.buffer {
+			text, style := t.gutter(line)
+			gutterMarkers[line] = gutterMarker{text: text, style: style}
+			if w := TaggedStringWidth(text); w > gutterWidth {
+				gutterWidth = w
+			}
+		}
+		if gutterWidth >= width {
+			gutterWidth = 0 // No space left for the text.
+		}
+		x += gutterWidth
+		width -= gutterWidth
+	}
+
+	// Determine whether we show a scroll bar. In auto mode, we index with the
+	// width of the last draw call so we don't reindex twice on every draw.
+	showScrollBar := t.scrollBarVisibility == ScrollBarAlways && width > 1
+	if t.scrollBarVisibility == ScrollBarAuto && width > 1 {
+		indexWidth := width
+		if t.scrollBarShown {
+			indexWidth--
+		}
+		indexWidth = t.textWidth(indexWidth)
+		if indexWidth != t.lastWidth && t.wrap {
+			t.index = nil
+		}
+		t.lastWidth = indexWidth
+		t.reindexBuffer(indexWidth)
+		showScrollBar = len(t.index) > height
+	}
+	t.scrollBarShown = showScrollBar
+	if showScrollBar {
+		width--
+	}
+	scrollBarX := x + width
+
+	// Narrow the text area to the wrap width.
+	if textWidth := t.textWidth(width); textWidth < width {
+		if t.align == AlignRight {
+			x += width - textWidth
+		} else if t.align == AlignCenter {
+			x += (width - textWidth) / 2
+		}
+		width = textWidth
+	}
+
 	// If the width has changed, we need to reindex.
 	if width != t.lastWidth && t.wrap {
 		t.index = nil
@@ -1060,15 +1466,21 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 	// Draw the buffer.
 	defaultStyle := tcell.StyleDefault.Foreground(t.textColor).Background(t.backgroundColor)
+	lineTags := textViewLineTags{line: -1}
 	for line := t.lineOffset; line < len(t.index); line++ {
 		// Are we done?
 		if line-t.lineOffset >= height || y+line-t.lineOffset >= totalHeight {
 			break
 		}
 
-		// Get the text for this line.
+		// Get the index entry for this line.
 		index := t.index[line]
-		text := t.buffer[index.Line][index.Pos:index.NextPos]
+
+		// Draw the gutter marker.
+		if gutterWidth > 0 && index.Line < len(gutterMarkers) && (line == 0 || t.index[line-1].Line != index.Line) {
+			marker := gutterMarkers[index.Line]
+			printWithStyle(screen, marker.text, gutterX, y+line-t.lineOffset, 0, gutterWidth, AlignRight, marker.style, true)
+		}
 		foregroundColor := index.ForegroundColor
 		backgroundColor := index.BackgroundColor
 		attributes := index.Attributes
@@ -1084,11 +1496,11 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		}
 
 		// Process tags.
-		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedText, _ := decomposeString(string(text), t.dynamicColors, t.regions)
+		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedText := t.decomposeIndexLine(index, &lineTags)
 		// Calculate the position of the line.
 		var skip, posX int
 		if t.align == AlignLeft {
-			posX = -t.columnOffset
+			posX = index.Indent - t.columnOffset
 		} else if t.align == AlignRight {
 			posX = width - index.Width - t.columnOffset
 		} else { // AlignCenter.
@@ -1193,6 +1605,11 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Draw the scroll bar.
+	if showScrollBar {
+		drawScrollBar(screen, scrollBarX, y, height, len(t.index), t.lineOffset, t.scrollBarColor, t.scrollBarThumbColor, t.backgroundColor)
+	}
+
 	// If this view is not scrollable, we'll purge the buffer of lines that have
 	// scrolled out of view.
 	if !t.scrollable && t.lineOffset > 0 {
@@ -1281,12 +1698,38 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 		x, y := event.Position()
+
+		// Drag the scroll bar's thumb.
+		if t.scrollBarDragging {
+			switch action {
+			case MouseMove:
+				t.scrollBarScrollTo(y)
+				return true, t
+			case MouseLeftUp:
+				t.scrollBarDragging = false
+				return true, nil
+			}
+		}
+
 		if !t.InRect(x, y) {
 			return false, nil
 		}
+		rectX, rectY, rectWidth, rectHeight := t.GetInnerRect()
+		onScrollBar := t.scrollBarShown && x == rectX+rectWidth-1 && y >= rectY && y < rectY+rectHeight
 
 		switch action {
+		case MouseLeftDown:
+			if onScrollBar {
+				setFocus(t)
+				t.scrollBarDragging = true
+				t.scrollBarScrollTo(y)
+				return true, t
+			}
 		case MouseLeftClick:
+			if onScrollBar {
+				consumed = true
+				break
+			}
 			if t.regions {
 				// Find a region to highlight.
 				for _, region := range t.regionInfos {
@@ -1297,6 +1740,9 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 						continue
 					}
 					t.Highlight(region.ID)
+					if t.regionClicked != nil {
+						t.regionClicked(region.ID)
+					}
 					break
 				}
 			}
@@ -1314,3 +1760,14 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 		return
 	})
 }
+
+// scrollBarScrollTo scrolls the text view such that the scroll bar's thumb is
+// centered on the given screen row.
+func (t *TextView) scrollBarScrollTo(y int) {
+	if !t.scrollable {
+		return
+	}
+	_, rectY, _, height := t.GetInnerRect()
+	t.trackEnd = false
+	t.lineOffset = scrollBarOffset(y-rectY, height, len(t.index))
+}
@@ -0,0 +1,228 @@
+package tview
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// drawTextView draws the text view on a simulation screen of the given size
+// and returns the screen.
+func drawTextView(t *testing.T, textView *TextView, width, height int) tcell.SimulationScreen {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(width, height)
+	textView.SetRect(0, 0, width, height)
+	textView.Draw(screen)
+	return screen
+}
+
+// screenRow returns the text of the given row of the screen, without
+// trailing spaces.
+func screenRow(screen tcell.SimulationScreen, y int) string {
+	width, _ := screen.Size()
+	var b strings.Builder
+	for x := 0; x < width; x++ {
+		r, _, _, _ := screen.GetContent(x, y)
+		b.WriteRune(r)
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+func TestTextViewWrappedColors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		wordWrap bool
+		rows     []string
+		colors   []string // One letter per cell: r(ed), b(lue), or d(efault).
+	}{
+		{
+			name:   "span wraps twice",
+			text:   "[red::]aaaaaaaaaaaa[-::]bb",
+			width:  5,
+			rows:   []string{"aaaaa", "aaaaa", "aabb"},
+			colors: []string{"rrrrr", "rrrrr", "rrdd"},
+		},
+		{
+			name:   "color changes after the wrap",
+			text:   "[red::]aaaaaaa[blue::]bbbbbbb[-::]c",
+			width:  5,
+			rows:   []string{"aaaaa", "aabbb", "bbbbc"},
+			colors: []string{"rrrrr", "rrbbb", "bbbbd"},
+		},
+		{
+			name:   "escaped bracket at the wrap point",
+			text:   "[red::]abcd[x[]yzabcdefg[-::]h",
+			width:  5,
+			rows:   []string{"abcd[", "x]yza", "bcdef", "gh"},
+			colors: []string{"rrrrr", "rrrrr", "rrrrr", "rd"},
+		},
+		{
+			name:   "escaped bracket before the wrap point",
+			text:   "[red::]abc[x[]yzabcdefgh[-::]i",
+			width:  5,
+			rows:   []string{"abc[x", "]yzab", "cdefg", "hi"},
+			colors: []string{"rrrrr", "rrrrr", "rrrrr", "rd"},
+		},
+		{
+			name:     "word wrapped span",
+			text:     "[red::]one two three four[-::] five",
+			width:    6,
+			wordWrap: true,
+			rows:     []string{"one", "two", "three", "four", "five"},
+			colors:   []string{"rrr", "rrr", "rrrrr", "rrrr", "dddd"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			textView := NewTextView().
+				SetDynamicColors(true).
+				SetWrap(true).
+				SetWordWrap(test.wordWrap).
+				SetText(test.text)
+			screen := drawTextView(t, textView, test.width, len(test.rows)+1)
+			for y, row := range test.rows {
+				if got := screenRow(screen, y); got != row {
+					t.Errorf("row %d is %q, want %q", y, got, row)
+				}
+				for x, c := range test.colors[y] {
+					want := map[rune]tcell.Color{'r': tcell.ColorRed, 'b': tcell.ColorBlue, 'd': Styles.PrimaryTextColor}[c]
+					_, _, style, _ := screen.GetContent(x, y)
+					if fg, _, _ := style.Decompose(); fg != want {
+						t.Errorf("cell (%d, %d) has color %v, want %v", x, y, fg, want)
+					}
+				}
+			}
+			if got := screenRow(screen, len(test.rows)); got != "" {
+				t.Errorf("unexpected row %d: %q", len(test.rows), got)
+			}
+		})
+	}
+}
+
+func TestTextViewVerbatim(t *testing.T) {
+	tests := []string{
+		"[",
+		"]",
+		"a[b",
+		"a]b",
+		"[]",
+		"[red]text",
+		"[red::b]text[-::-]",
+		"[red[]",
+		`["region"]text[""]`,
+		"x := a[i][j]",
+		"[[[]]]",
+		"[:]",
+	}
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			textView := NewTextView().SetText(text)
+			screen := drawTextView(t, textView, 30, 1)
+			if got := screenRow(screen, 0); got != text {
+				t.Errorf("displayed %q, want %q", got, text)
+			}
+			if got := textView.GetText(true); got != text {
+				t.Errorf("GetText(true) = %q, want %q", got, text)
+			}
+		})
+	}
+}
+
+func TestTextViewGetTextStripped(t *testing.T) {
+	tests := []struct {
+		name             string
+		dynamic, regions bool
+		text, want       string
+	}{
+		{name: "colors", dynamic: true, text: "[red::]a[-::]b", want: "ab"},
+		{name: "escaped color tag", dynamic: true, text: "[red[]a", want: "[red]a"},
+		{name: "escaped color tag with regions", dynamic: true, regions: true, text: `["r"][red[][""]`, want: "[red]"},
+		{name: "escaped region tag", regions: true, text: `["r"[]a`, want: `["r"]a`},
+		{name: "colors are literal without dynamic colors", regions: true, text: `[red::]["r"]a[""]`, want: "[red::]a"},
+		{name: "regions are literal without regions", dynamic: true, text: `["r"]a[""]`, want: `["r"]a[""]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			textView := NewTextView().
+				SetDynamicColors(test.dynamic).
+				SetRegions(test.regions).
+				SetText(test.text)
+			if got := textView.GetText(true); got != test.want {
+				t.Errorf("GetText(true) = %q, want %q", got, test.want)
+			}
+			screen := drawTextView(t, textView, 30, 1)
+			if got := screenRow(screen, 0); got != test.want {
+				t.Errorf("displayed %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTextViewGetRegionText(t *testing.T) {
+	tests := []struct {
+		name     string
+		dynamic  bool
+		text     string
+		regionID string
+		want     string
+	}{
+		{name: "plain", text: `a["r"]bc[""]d`, regionID: "r", want: "bc"},
+		{name: "second region", text: `["a"]x["b"]y[""]`, regionID: "b", want: "y"},
+		{name: "missing region", text: `["a"]x[""]`, regionID: "b", want: ""},
+		{name: "brackets in region", text: `["r"]a[i] ]x[""]`, regionID: "r", want: "a[i] ]x"},
+		{name: "escaped tag in region", text: `["r"]a[i[]b[""]`, regionID: "r", want: "a[i]b"},
+		{name: "colors stripped", dynamic: true, text: `["r"][red::]a[-::]b[""]`, regionID: "r", want: "ab"},
+		{name: "colors kept without dynamic colors", text: `["r"][red::]a[""]`, regionID: "r", want: "[red::]a"},
+		{name: "region spans lines", text: "[\"r\"]a\nb[\"\"]c", regionID: "r", want: "a\nb"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			textView := NewTextView().
+				SetDynamicColors(test.dynamic).
+				SetRegions(true).
+				SetText(test.text)
+			if got := textView.GetRegionText(test.regionID); got != test.want {
+				t.Errorf("GetRegionText(%q) = %q, want %q", test.regionID, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTextViewBatchWritePanic(t *testing.T) {
+	textView := NewTextView()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("BatchWrite() did not pass on the panic")
+			}
+		}()
+		textView.BatchWrite(func(w io.Writer) {
+			fmt.Fprint(w, "before")
+			panic("failed")
+		})
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		fmt.Fprint(textView, " after")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("text view remained locked after a panic in BatchWrite()")
+	}
+	if got := textView.GetText(true); got != "before after" {
+		t.Errorf("GetText(true) = %q, want %q", got, "before after")
+	}
+}
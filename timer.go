@@ -0,0 +1,338 @@
+package tview
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// Timer displays a duration which counts down to zero (a countdown) or up from
+// zero (a stopwatch). The displayed time is derived from the system clock, so
+// it stays accurate regardless of how often the timer is drawn.
+//
+// The timer does not draw itself. While it is running, it calls the function
+// set with SetUpdateFunc() from a separate goroutine whenever the displayed
+// value changes. This function is expected to execute the provided function
+// on the main goroutine and redraw the screen, e.g.:
+//
+//	timer := tview.NewTimer(5 * time.Minute)
+//	timer.SetUpdateFunc(func(f func()) {
+//		app.QueueUpdateDraw(f)
+//	})
+//	timer.Start()
+type Timer struct {
+	*Box
+
+	sync.Mutex
+
+	// The duration to count down from. If 0, the timer counts up.
+	duration time.Duration
+
+	// The time accumulated before the timer was last started.
+	elapsed time.Duration
+
+	// The time the timer was last started. Zero if the timer is stopped.
+	started time.Time
+
+	// The timer which wakes the timer up when the displayed value changes and
+	// a counter which identifies it. Outdated tickers are ignored.
+	ticker     *time.Timer
+	generation int
+
+	// An optional function which executes the given function on the main
+	// goroutine and redraws the screen.
+	update func(f func())
+
+	// An optional function which is called when the countdown reaches zero.
+	expired func()
+
+	// An optional function which formats the displayed duration.
+	format func(d time.Duration) string
+
+	// The text color.
+	textColor tcell.Color
+
+	// The remaining time at or below which the warning color is used instead of
+	// the text color. Ignored if the timer counts up.
+	warningThreshold time.Duration
+
+	// The text color used when the remaining time falls below the threshold.
+	warningColor tcell.Color
+
+	// The horizontal alignment of the text.
+	align int
+}
+
+// NewTimer returns a new timer which counts down from the given duration. If
+// the duration is 0, the timer counts up from zero instead. The timer is
+// initially stopped.
+func NewTimer(duration time.Duration) *Timer {
+	return &Timer{
+		Box:          NewBox(),
+		duration:     duration,
+		textColor:    Styles.PrimaryTextColor,
+		warningColor: tcell.ColorRed,
+		align:        AlignCenter,
+	}
+}
+
+// SetDuration sets the duration the timer counts down from. If 0, the timer
+// counts up. The time elapsed so far is kept.
+func (t *Timer) SetDuration(duration time.Duration) *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.duration = duration
+	return t
+}
+
+// SetUpdateFunc sets a function which the timer calls from its own goroutine
+// while it is running, each time the displayed value changes. The function
+// receives another function which must be executed on the main goroutine,
+// after which the screen should be redrawn. Typically, this function calls
+// Application.QueueUpdateDraw(). If no update function is set, the timer
+// calls the provided function directly from its goroutine.
+func (t *Timer) SetUpdateFunc(handler func(f func())) *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.update = handler
+	return t
+}
+
+// SetExpiredFunc sets a handler which is called once when the countdown
+// reaches zero. The timer is stopped at that point. The handler is called on
+// the main goroutine (via the function set with SetUpdateFunc()).
+func (t *Timer) SetExpiredFunc(handler func()) *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.expired = handler
+	return t
+}
+
+// SetFormatFunc sets a function which formats the displayed duration. The
+// duration is rounded up to full seconds for countdowns and down to full
+// seconds when counting up. The default format is "mm:ss", or "hh:mm:ss" for
+// durations of an hour or longer. The returned text may contain color tags.
+// Provide nil to restore the default format.
+func (t *Timer) SetFormatFunc(format func(d time.Duration) string) *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.format = format
+	return t
+}
+
+// SetTextColor sets the color of the displayed time.
+func (t *Timer) SetTextColor(color tcell.Color) *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.textColor = color
+	return t
+}
+
+// SetWarning sets a color which is used instead of the text color when the
+// remaining time of a countdown is at or below the given threshold. A
+// threshold of 0 (the default) disables the warning color.
+func (t *Timer) SetWarning(threshold time.Duration, color tcell.Color) *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.warningThreshold = threshold
+	t.warningColor = color
+	return t
+}
+
+// SetAlign sets the horizontal alignment of the displayed time, one of
+// AlignLeft, AlignCenter (the default), or AlignRight.
+func (t *Timer) SetAlign(align int) *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.align = align
+	return t
+}
+
+// Start starts or resumes the timer. Nothing happens if the timer is already
+// running or if a countdown has already expired.
+func (t *Timer) Start() *Timer {
+	t.Lock()
+	defer t.Unlock()
+	if !t.started.IsZero() || t.duration > 0 && t.elapsed >= t.duration {
+		return t
+	}
+	t.started = time.Now()
+	t.schedule()
+	return t
+}
+
+// Stop stops the timer, keeping the time elapsed so far. Use Start() to
+// resume it.
+func (t *Timer) Stop() *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.stop()
+	return t
+}
+
+// Reset sets the elapsed time back to zero. A running timer keeps running.
+func (t *Timer) Reset() *Timer {
+	t.Lock()
+	defer t.Unlock()
+	t.elapsed = 0
+	if !t.started.IsZero() {
+		t.started = time.Now()
+		t.schedule()
+	}
+	return t
+}
+
+// IsRunning returns whether the timer is currently running.
+func (t *Timer) IsRunning() bool {
+	t.Lock()
+	defer t.Unlock()
+	return !t.started.IsZero()
+}
+
+// GetElapsed returns the time elapsed since the timer was started, excluding
+// the time it was stopped.
+func (t *Timer) GetElapsed() time.Duration {
+	t.Lock()
+	defer t.Unlock()
+	return t.getElapsed()
+}
+
+// GetRemaining returns the remaining time of a countdown, or 0 if the timer
+// counts up or has expired.
+func (t *Timer) GetRemaining() time.Duration {
+	t.Lock()
+	defer t.Unlock()
+	return t.getRemaining()
+}
+
+// getElapsed returns the elapsed time. The timer must be locked.
+func (t *Timer) getElapsed() time.Duration {
+	elapsed := t.elapsed
+	if !t.started.IsZero() {
+		elapsed += time.Since(t.started)
+	}
+	return elapsed
+}
+
+// getRemaining returns the remaining time. The timer must be locked.
+func (t *Timer) getRemaining() time.Duration {
+	if t.duration <= 0 {
+		return 0
+	}
+	remaining := t.duration - t.getElapsed()
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining
+}
+
+// stop stops the timer. The timer must be locked.
+func (t *Timer) stop() {
+	if t.started.IsZero() {
+		return
+	}
+	t.elapsed += time.Since(t.started)
+	t.started = time.Time{}
+	t.generation++
+	if t.ticker != nil {
+		t.ticker.Stop()
+		t.ticker = nil
+	}
+}
+
+// schedule arranges for tick() to be called when the displayed value changes
+// next. The timer must be locked.
+func (t *Timer) schedule() {
+	if t.ticker != nil {
+		t.ticker.Stop()
+	}
+	var wait time.Duration
+	if t.duration > 0 {
+		wait = t.getRemaining() % time.Second
+	} else {
+		wait = time.Second - t.getElapsed()%time.Second
+	}
+	if wait <= 0 {
+		wait = time.Second
+	}
+	t.generation++
+	generation := t.generation
+	t.ticker = time.AfterFunc(wait+10*time.Millisecond, func() {
+		t.tick(generation)
+	})
+}
+
+// tick is called from the goroutine of the ticker with the given generation
+// when the displayed value changes.
+func (t *Timer) tick(generation int) {
+	t.Lock()
+	if t.generation != generation {
+		t.Unlock()
+		return // This ticker was stopped or replaced.
+	}
+	update := t.update
+	if t.duration <= 0 || t.getRemaining() > 0 {
+		t.schedule()
+	}
+	t.Unlock()
+
+	f := func() {
+		t.Lock()
+		var expired func()
+		if t.generation == generation && t.duration > 0 && t.getRemaining() == 0 {
+			t.stop()
+			t.elapsed = t.duration
+			expired = t.expired
+		}
+		t.Unlock()
+		if expired != nil {
+			expired()
+		}
+	}
+	if update != nil {
+		update(f)
+	} else {
+		f()
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (t *Timer) Draw(screen tcell.Screen) {
+	t.Box.DrawForSubclass(screen, t)
+
+	x, y, width, height := t.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	t.Lock()
+	var shown time.Duration
+	color := t.textColor
+	if t.duration > 0 {
+		remaining := t.getRemaining()
+		shown = (remaining + time.Second - 1) / time.Second * time.Second
+		if t.warningThreshold > 0 && remaining <= t.warningThreshold {
+			color = t.warningColor
+		}
+	} else {
+		shown = t.getElapsed() / time.Second * time.Second
+	}
+	format := t.format
+	align := t.align
+	t.Unlock()
+
+	var text string
+	if format != nil {
+		text = format(shown)
+	} else {
+		seconds := int(shown / time.Second)
+		if seconds >= 3600 {
+			text = fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+		} else {
+			text = fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+		}
+	}
+	Print(screen, text, x, y, width, align, color)
+}
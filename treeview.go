@@ -28,6 +28,9 @@ type TreeNode struct {
 	// The item's text.
 	text string
 
+	// An optional secondary text which is right-aligned in the tree view.
+	secondaryText string
+
 	// The text color.
 	color tcell.Color
 
@@ -49,9 +52,25 @@ type TreeNode struct {
 	level int
 
 	// Temporary member variables.
-	parent    *TreeNode // The parent node (nil for the root).
-	graphicsX int       // The x-coordinate of the left-most graphics rune.
-	textX     int       // The x-coordinate of the first rune of the text.
+	parent        *TreeNode // The parent node (nil for the root).
+	graphicsX     int       // The x-coordinate of the left-most graphics rune.
+	textX         int       // The x-coordinate of the first rune of the text.
+	baseGraphicsX int       // The graphics x-coordinate before alignment.
+	baseTextX     int       // The text x-coordinate before alignment.
+	row           int       // The index in the tree view's visible nodes.
+	visibleRows   int       // The number of visible nodes in this subtree.
+
+	// Change tracking for tree views. The version is incremented for this node
+	// and all its ancestors when the subtree changes in a way which affects the
+	// visible nodes. Tree views rebuild the rows of subtrees whose version
+	// differs from the one they were built with, starting at the nodes marked
+	// "dirty" (the nodes which changed themselves). Versions are propagated
+	// through the parent links which the functions adding children maintain.
+	version, builtVersion uint64
+	dirty                 bool
+
+	// The tree view which last built its visible nodes from this (root) node.
+	builtBy *TreeView
 }
 
 // NewTreeNode returns a new tree node.
@@ -71,13 +90,16 @@ func NewTreeNode(text string) *TreeNode {
 // The callback returns whether traversal should continue with the traversed
 // node's child nodes (true) or not recurse any deeper (false).
 func (n *TreeNode) Walk(callback func(node, parent *TreeNode) bool) *TreeNode {
-	n.parent = nil
 	nodes := []*TreeNode{n}
 	for len(nodes) > 0 {
 		// Pop the top node and process it.
 		node := nodes[len(nodes)-1]
 		nodes = nodes[:len(nodes)-1]
-		if !callback(node, node.parent) {
+		parent := node.parent
+		if node == n {
+			parent = nil
+		}
+		if !callback(node, parent) {
 			// Don't add any children.
 			continue
 		}
@@ -92,6 +114,15 @@ func (n *TreeNode) Walk(callback func(node, parent *TreeNode) bool) *TreeNode {
 	return n
 }
 
+// changed marks this node as changed in a way which affects the visible nodes
+// of its subtree so that tree views rebuild them.
+func (n *TreeNode) changed() {
+	n.dirty = true
+	for node := n; node != nil; node = node.parent {
+		node.version++
+	}
+}
+
 // SetReference allows you to store a reference of any type in this node. This
 // will allow you to establish a mapping between the TreeView hierarchy and your
 // internal tree structure.
@@ -108,6 +139,10 @@ func (n *TreeNode) GetReference() interface{} {
 // SetChildren sets this node's child nodes.
 func (n *TreeNode) SetChildren(childNodes []*TreeNode) *TreeNode {
 	n.children = childNodes
+	for _, child := range childNodes {
+		child.parent = n
+	}
+	n.changed()
 	return n
 }
 
@@ -116,7 +151,8 @@ func (n *TreeNode) GetText() string {
 	return n.text
 }
 
-// GetChildren returns this node's children.
+// GetChildren returns this node's children. Tree views do not notice changes
+// made directly to the returned slice; call SetChildren() afterwards.
 func (n *TreeNode) GetChildren() []*TreeNode {
 	return n.children
 }
@@ -124,12 +160,30 @@ func (n *TreeNode) GetChildren() []*TreeNode {
 // ClearChildren removes all child nodes from this node.
 func (n *TreeNode) ClearChildren() *TreeNode {
 	n.children = nil
+	n.changed()
 	return n
 }
 
 // AddChild adds a new child node to this node.
 func (n *TreeNode) AddChild(node *TreeNode) *TreeNode {
 	n.children = append(n.children, node)
+	node.parent = n
+	n.changed()
+	return n
+}
+
+// InsertChild inserts a new child node at the given position, starting with 0
+// for the first child. If the index is negative or beyond the last child, the
+// node is added at the end.
+func (n *TreeNode) InsertChild(index int, node *TreeNode) *TreeNode {
+	if index < 0 || index >= len(n.children) {
+		return n.AddChild(node)
+	}
+	n.children = append(n.children, nil)
+	copy(n.children[index+1:], n.children[index:])
+	n.children[index] = node
+	node.parent = n
+	n.changed()
 	return n
 }
 
@@ -139,6 +193,10 @@ func (n *TreeNode) RemoveChild(node *TreeNode) *TreeNode {
 	for index, child := range n.children {
 		if child == node {
 			n.children = append(n.children[:index], n.children[index+1:]...)
+			if node.parent == n {
+				node.parent = nil
+			}
+			n.changed()
 			break
 		}
 	}
@@ -146,7 +204,10 @@ func (n *TreeNode) RemoveChild(node *TreeNode) *TreeNode {
 }
 
 // SetSelectable sets a flag indicating whether this node can be selected by
-// the user.
+// the user. Nodes are selectable by default. Non-selectable nodes are useful
+// as structural headers: keyboard navigation skips them and clicking them
+// expands or collapses their children instead of selecting them. No "changed"
+// or "selected" events are fired for them.
 func (n *TreeNode) SetSelectable(selectable bool) *TreeNode {
 	n.selectable = selectable
 	return n
@@ -162,18 +223,21 @@ func (n *TreeNode) SetSelectedFunc(handler func()) *TreeNode {
 // SetExpanded sets whether or not this node's child nodes should be displayed.
 func (n *TreeNode) SetExpanded(expanded bool) *TreeNode {
 	n.expanded = expanded
+	n.changed()
 	return n
 }
 
 // Expand makes the child nodes of this node appear.
 func (n *TreeNode) Expand() *TreeNode {
 	n.expanded = true
+	n.changed()
 	return n
 }
 
 // Collapse makes the child nodes of this node disappear.
 func (n *TreeNode) Collapse() *TreeNode {
 	n.expanded = false
+	n.changed()
 	return n
 }
 
@@ -183,6 +247,7 @@ func (n *TreeNode) ExpandAll() *TreeNode {
 		node.expanded = true
 		return true
 	})
+	n.changed()
 	return n
 }
 
@@ -192,6 +257,7 @@ func (n *TreeNode) CollapseAll() *TreeNode {
 		n.expanded = false
 		return true
 	})
+	n.changed()
 	return n
 }
 
@@ -206,6 +272,24 @@ func (n *TreeNode) SetText(text string) *TreeNode {
 	return n
 }
 
+// SetSecondaryText sets a secondary text for this node which is displayed
+// right-aligned at the right edge of the tree view, e.g. a file size or a
+// count (see also TreeView.SetSecondaryTextColor()). If space is tight, the
+// main text is truncated first. The secondary text is only truncated where it
+// would take up more than half of the width remaining after the node's
+// indentation. When such a node is the current node, the highlight
+// spans the entire width up to the right edge, including the secondary text.
+// Provide an empty string to remove the secondary text.
+func (n *TreeNode) SetSecondaryText(text string) *TreeNode {
+	n.secondaryText = text
+	return n
+}
+
+// GetSecondaryText returns the node's secondary text.
+func (n *TreeNode) GetSecondaryText() string {
+	return n.secondaryText
+}
+
 // GetColor returns the node's color.
 func (n *TreeNode) GetColor() tcell.Color {
 	return n.color
@@ -219,9 +303,11 @@ func (n *TreeNode) SetColor(color tcell.Color) *TreeNode {
 
 // SetIndent sets an additional indentation for this node's text. A value of 0
 // keeps the text as far left as possible with a minimum of line graphics. Any
-// value greater than that moves the text to the right.
+// value greater than that moves the text to the right. This is ignored if an
+// indent was set for the entire tree with TreeView.SetIndent().
 func (n *TreeNode) SetIndent(indent int) *TreeNode {
 	n.indent = indent
+	n.changed()
 	return n
 }
 
@@ -292,16 +378,33 @@ type TreeView struct {
 	// If set to true, all node texts will be aligned horizontally.
 	align bool
 
+	// The number of columns each hierarchy level is indented by. If negative,
+	// each node's own indent is used.
+	indent int
+
 	// If set to true, the tree structure is drawn using lines.
 	graphics bool
 
 	// The color of the lines.
 	graphicsColor tcell.Color
 
+	// The color of the nodes' secondary texts.
+	secondaryTextColor tcell.Color
+
+	// An optional function which returns the style of a node's text.
+	nodeStyle func(node *TreeNode) tcell.Style
+
 	// An optional function which is called when the user has navigated to a new
 	// tree node.
 	changed func(node *TreeNode)
 
+	// If set to true, the "changed" function is not called for the node which
+	// is selected when the tree view is first drawn.
+	noInitialChanged bool
+
+	// Set to true once the tree view was drawn with a root node.
+	drawn bool
+
 	// An optional function which is called when a tree item was selected.
 	selected func(node *TreeNode)
 
@@ -311,20 +414,28 @@ type TreeView struct {
 
 	// The visible nodes, top-down, as set by process().
 	nodes []*TreeNode
+
+	// Whether "nodes" was built with the current root and layout settings. If
+	// not, process() rebuilds it entirely. Otherwise, it only rebuilds the
+	// parts of the tree which changed.
+	nodesValid bool
 }
 
 // NewTreeView returns a new tree view.
 func NewTreeView() *TreeView {
 	return &TreeView{
-		Box:           NewBox(),
-		graphics:      true,
-		graphicsColor: Styles.GraphicsColor,
+		Box:                NewBox(),
+		graphics:           true,
+		graphicsColor:      Styles.GraphicsColor,
+		secondaryTextColor: Styles.TertiaryTextColor,
+		indent:             -1,
 	}
 }
 
 // SetRoot sets the root node of the tree.
 func (t *TreeView) SetRoot(root *TreeNode) *TreeView {
 	t.root = root
+	t.nodesValid = false
 	return t
 }
 
@@ -338,7 +449,9 @@ func (t *TreeView) GetRoot() *TreeNode {
 // selections. Selected nodes must be visible and selectable, or else the
 // selection will be changed to the top-most selectable and visible node.
 //
-// This function does NOT trigger the "changed" callback.
+// This function does NOT trigger the "changed" callback. However, if the tree
+// view has not been drawn yet, the callback is called for the selected node
+// when it is first drawn (see SetInitialChanged()).
 func (t *TreeView) SetCurrentNode(node *TreeNode) *TreeView {
 	t.currentNode = node
 	return t
@@ -350,11 +463,54 @@ func (t *TreeView) GetCurrentNode() *TreeNode {
 	return t.currentNode
 }
 
+// MoveNode moves a node, including its subtree, so it becomes the child of
+// "newParent" at the given position among its children (see
+// TreeNode.InsertChild()). This can also be used to reorder siblings. The
+// expansion state of the moved nodes is kept. If the moved node is the current
+// node, it remains selected and "newParent" is expanded to keep it visible.
+//
+// Both nodes must be part of this tree view's tree. The root node cannot be
+// moved, nor can a node be moved into its own subtree. In these cases, false
+// is returned and the tree remains unchanged.
+func (t *TreeView) MoveNode(node, newParent *TreeNode, index int) bool {
+	if t.root == nil || node == nil || newParent == nil || node == t.root {
+		return false
+	}
+
+	// Find the current parent and make sure the new parent is not part of the
+	// moved subtree.
+	var oldParent *TreeNode
+	newParentFound := false
+	t.root.Walk(func(n, parent *TreeNode) bool {
+		if n == node {
+			oldParent = parent
+			return false // Don't descend into the moved subtree.
+		}
+		if n == newParent {
+			newParentFound = true
+		}
+		return true
+	})
+	if oldParent == nil || !newParentFound {
+		return false
+	}
+
+	// Detach and reinsert. When reordering among siblings, the index refers to
+	// the position after the move.
+	oldParent.RemoveChild(node)
+	newParent.InsertChild(index, node)
+	if node == t.currentNode {
+		newParent.Expand()
+	}
+	return true
+}
+
 // SetTopLevel sets the first tree level that is visible with 0 referring to the
 // root, 1 to the root's child nodes, and so on. Nodes above the top level are
 // not displayed.
 func (t *TreeView) SetTopLevel(topLevel int) *TreeView {
 	t.topLevel = topLevel
+	t.nodesValid = false
 	return t
 }
 
@@ -377,13 +533,33 @@ func (t *TreeView) SetPrefixes(prefixes []string) *TreeView {
 // If set to false, they will indent with the hierarchy.
 func (t *TreeView) SetAlign(align bool) *TreeView {
 	t.align = align
+	t.nodesValid = false
 	return t
 }
 
+// SetIndent sets the number of columns by which each hierarchy level is
+// indented relative to its parent, including the column taken by line
+// graphics (if enabled). This overrides the indents of individual nodes (see
+// TreeNode.SetIndent()). A negative value (the default) restores the nodes'
+// individual indents. While line graphics are drawn (see SetGraphics()), an
+// indent of 0 is treated as 1 so that the text does not cover the lines.
+func (t *TreeView) SetIndent(indent int) *TreeView {
+	t.indent = indent
+	t.nodesValid = false
+	return t
+}
+
+// GetIndent returns the number of columns by which each hierarchy level is
+// indented or a negative value if the nodes' individual indents are used.
+func (t *TreeView) GetIndent() int {
+	return t.indent
+}
+
 // SetGraphics sets a flag which determines whether or not line graphics are
 // drawn to illustrate the tree's hierarchy.
 func (t *TreeView) SetGraphics(showGraphics bool) *TreeView {
 	t.graphics = showGraphics
+	t.nodesValid = false
 	return t
 }
 
@@ -393,13 +569,43 @@ func (t *TreeView) SetGraphicsColor(color tcell.Color) *TreeView {
 	return t
 }
 
+// SetNodeStyleFunc sets a function which is called for each visible node when
+// the tree view is drawn and which returns a style for the node's text, e.g.
+// to color nodes based on their data. The foreground and background colors of
+// the returned style replace the node's color (see TreeNode.SetColor()) and the
+// tree view's background color unless they are tcell.ColorDefault. The style's
+// attributes (e.g. bold) are applied as well. As usual, the colors of the
+// current node are inverted. Provide nil to remove the function.
+func (t *TreeView) SetNodeStyleFunc(handler func(node *TreeNode) tcell.Style) *TreeView {
+	t.nodeStyle = handler
+	return t
+}
+
+// SetSecondaryTextColor sets the color of the nodes' secondary texts (see
+// TreeNode.SetSecondaryText()).
+func (t *TreeView) SetSecondaryTextColor(color tcell.Color) *TreeView {
+	t.secondaryTextColor = color
+	return t
+}
+
 // SetChangedFunc sets the function which is called when the user navigates to
-// a new tree node.
+// a new tree node. It is also called once for the node which is selected when
+// the tree view is first drawn, e.g. the node provided to SetCurrentNode()
+// during setup, so that observers can be initialized. Use SetInitialChanged()
+// to suppress this first call.
 func (t *TreeView) SetChangedFunc(handler func(node *TreeNode)) *TreeView {
 	t.changed = handler
 	return t
 }
 
+// SetInitialChanged sets whether the "changed" function is called for the node
+// which is selected when the tree view is first drawn (the default). Nothing
+// is called if no node is selected at that time.
+func (t *TreeView) SetInitialChanged(enabled bool) *TreeView {
+	t.noInitialChanged = !enabled
+	return t
+}
+
 // SetSelectedFunc sets the function which is called when the user selects a
 // node by pressing Enter on the current selection.
 func (t *TreeView) SetSelectedFunc(handler func(node *TreeNode)) *TreeView {
@@ -429,83 +635,32 @@ func (t *TreeView) GetRowCount() int {
 	return len(t.nodes)
 }
 
-// process builds the visible tree, populates the "nodes" slice, and processes
-// pending selection actions.
+// process builds the visible tree if needed, populates the "nodes" slice, and
+// processes pending selection actions.
 func (t *TreeView) process() {
 	_, _, _, height := t.GetInnerRect()
-
-	// Determine visible nodes and their placement.
-	var graphicsOffset, maxTextX, parentSelectedIndex int
-	t.nodes = nil
 	if t.root == nil {
+		t.nodes = nil
+		t.nodesValid = false
 		return
 	}
-	selectedIndex := -1
-	topLevelGraphicsX := -1
-	if t.graphics {
-		graphicsOffset = 1
-	}
-	t.root.Walk(func(node, parent *TreeNode) bool {
-		// Set node attributes.
-		node.parent = parent
-		if parent == nil {
-			node.level = 0
-			node.graphicsX = 0
-			node.textX = 0
-		} else {
-			node.level = parent.level + 1
-			node.graphicsX = parent.textX
-			node.textX = node.graphicsX + graphicsOffset + node.indent
-		}
-		if !t.graphics && t.align {
-			// Without graphics, we align nodes on the first column.
-			node.textX = 0
-		}
-		if node.level == t.topLevel {
-			// No graphics for top level nodes.
-			node.graphicsX = 0
-			node.textX = 0
-		}
-
-		// Add the node to the list.
-		if node.level >= t.topLevel {
-			// This node will be visible.
-			if node.textX > maxTextX {
-				maxTextX = node.textX
-			}
-			if node == t.currentNode && node.selectable {
-				selectedIndex = len(t.nodes)
-			}
-
-			// Maybe we want to skip this level.
-			if t.topLevel == node.level && (topLevelGraphicsX < 0 || node.graphicsX < topLevelGraphicsX) {
-				topLevelGraphicsX = node.graphicsX
-			}
-
-			t.nodes = append(t.nodes, node)
-		}
-
-		// Keep track of the parent of the selected node.
-		if selectedIndex < 0 && node.selectable && len(node.children) > 0 && node.expanded {
-			parentSelectedIndex = len(t.nodes) - 1
-		}
 
-		// Recurse if desired.
-		return node.expanded
-	})
-
-	// Post-process positions.
-	for _, node := range t.nodes {
-		// If text must align, we correct the positions.
-		if t.align && node.level > t.topLevel {
-			node.textX = maxTextX
-		}
+	// Rebuild the visible nodes if the layout changed or another tree view
+	// used the same nodes. Otherwise, only the changed subtrees are updated.
+	if !t.nodesValid || t.root.builtBy != t {
+		t.nodes = t.appendNodes(make([]*TreeNode, 0, len(t.nodes)), t.root, nil)
+		t.layoutNodes(0)
+		t.nodesValid, t.root.builtBy = true, t
+	} else if t.root.version != t.root.builtVersion {
+		firstRow := len(t.nodes)
+		t.updateNodes(t.root, nil, 0, &firstRow)
+		t.layoutNodes(firstRow)
+	}
 
-		// If we skipped levels, shift to the left.
-		if topLevelGraphicsX > 0 {
-			node.graphicsX -= topLevelGraphicsX
-			node.textX -= topLevelGraphicsX
-		}
+	// Find the selected node.
+	selectedIndex := -1
+	if node := t.currentNode; node != nil && node.selectable && node.row >= 0 && node.row < len(t.nodes) && t.nodes[node.row] == node {
+		selectedIndex = node.row
 	}
 
 	// Process selection. (Also trigger events if necessary.)
@@ -569,7 +724,13 @@ func (t *TreeView) process() {
 			}
 			newSelectedIndex = selectedIndex
 		case treeParent:
-			newSelectedIndex = parentSelectedIndex
+			newSelectedIndex = 0
+			for index := selectedIndex - 1; index >= 0; index-- {
+				if node := t.nodes[index]; node.selectable && len(node.children) > 0 && node.expanded {
+					newSelectedIndex = index
+					break
+				}
+			}
 		case treeChild:
 			for newSelectedIndex < len(t.nodes)-1 {
 				newSelectedIndex++
@@ -612,6 +773,132 @@ func (t *TreeView) process() {
 	}
 }
 
+// appendNodes places the given node, whose parent is "parent" (nil for the
+// root), and its subtree and appends the visible ones to "rows". The extended
+// slice is returned.
+func (t *TreeView) appendNodes(rows []*TreeNode, node, parent *TreeNode) []*TreeNode {
+	// Set node attributes.
+	var graphicsOffset int
+	if t.graphics {
+		graphicsOffset = 1
+	}
+	if parent == nil {
+		node.level = 0
+		node.baseGraphicsX = 0
+		node.baseTextX = 0
+	} else {
+		node.parent = parent
+		node.level = parent.level + 1
+		node.baseGraphicsX = parent.baseTextX
+		if t.indent >= 0 {
+			indent := t.indent
+			if indent < graphicsOffset {
+				indent = graphicsOffset // Leave room for the line graphics.
+			}
+			node.baseTextX = node.baseGraphicsX + indent
+		} else {
+			node.baseTextX = node.baseGraphicsX + graphicsOffset + node.indent
+		}
+	}
+	if !t.graphics && t.align {
+		// Without graphics, we align nodes on the first column.
+		node.baseTextX = 0
+	}
+	if node.level == t.topLevel {
+		// No graphics for top level nodes.
+		node.baseGraphicsX = 0
+		node.baseTextX = 0
+	}
+	node.graphicsX, node.textX = node.baseGraphicsX, node.baseTextX
+
+	// Add the node to the list if it is visible, then its children if desired.
+	start := len(rows)
+	if node.level >= t.topLevel {
+		rows = append(rows, node)
+	}
+	if node.expanded {
+		for _, child := range node.children {
+			rows = t.appendNodes(rows, child, node)
+		}
+	}
+	node.visibleRows = len(rows) - start
+	node.builtVersion, node.dirty = node.version, false
+	return rows
+}
+
+// updateNodes rebuilds the rows of those parts of the subtree of the given
+// node which changed since they were built. The subtree's rows start at
+// "start" in the "nodes" slice. Returns the number of rows by which the
+// subtree grew (negative if it shrank). Row indices are not updated but
+// "firstRow" is lowered to the first row which was replaced.
+func (t *TreeView) updateNodes(node, parent *TreeNode, start int, firstRow *int) int {
+	if node.version == node.builtVersion {
+		return 0 // Nothing changed here.
+	}
+
+	// Replace the rows of a changed node's subtree.
+	if node.dirty {
+		previousRows := node.visibleRows
+		rows := t.appendNodes(nil, node, parent)
+		delta := len(rows) - previousRows
+		end := len(t.nodes)
+		if delta > 0 {
+			t.nodes = append(t.nodes, rows[:delta]...) // Make room.
+		}
+		copy(t.nodes[start+len(rows):], t.nodes[start+previousRows:end])
+		copy(t.nodes[start:], rows)
+		if delta < 0 {
+			for index := end + delta; index < end; index++ {
+				t.nodes[index] = nil
+			}
+			t.nodes = t.nodes[:end+delta]
+		}
+		if start < *firstRow {
+			*firstRow = start
+		}
+		return delta
+	}
+
+	// Look for changes further down.
+	var delta int
+	if node.expanded {
+		row := start
+		if node.level >= t.topLevel {
+			row++
+		}
+		for _, child := range node.children {
+			delta += t.updateNodes(child, node, row, firstRow)
+			row += child.visibleRows
+		}
+	}
+	node.visibleRows += delta
+	node.builtVersion = node.version
+	return delta
+}
+
+// layoutNodes updates the row indices of the visible nodes, starting at the
+// given row, and aligns their texts if requested. The alignment depends on all
+// visible nodes so it always covers all rows.
+func (t *TreeView) layoutNodes(firstRow int) {
+	for row := firstRow; row < len(t.nodes); row++ {
+		t.nodes[row].row = row
+	}
+	if !t.align {
+		return
+	}
+	var maxTextX int
+	for _, node := range t.nodes {
+		if node.baseTextX > maxTextX {
+			maxTextX = node.baseTextX
+		}
+	}
+	for _, node := range t.nodes {
+		if node.level > t.topLevel {
+			node.textX = maxTextX
+		}
+	}
+}
+
 // Draw draws this primitive onto the screen.
 func (t *TreeView) Draw(screen tcell.Screen) {
 	t.Box.DrawForSubclass(screen, t)
@@ -622,6 +909,14 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 
 	t.process()
 
+	// Report the initial selection.
+	if !t.drawn {
+		t.drawn = true
+		if !t.noInitialChanged && t.currentNode != nil && t.changed != nil {
+			t.changed(t.currentNode)
+		}
+	}
+
 	// Scroll the tree.
 	x, y, width, height := t.GetInnerRect()
 	switch t.movement {
@@ -651,26 +946,25 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 	// Draw the tree.
 	posY := y
 	lineStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.graphicsColor)
-	for index, node := range t.nodes {
+	for index := t.offsetY; index < len(t.nodes); index++ {
+		node := t.nodes[index]
+
 		// Skip invisible parts.
 		if posY >= y+height+1 || posY >= totalHeight {
 			break
 		}
-		if index < t.offsetY {
-			continue
-		}
 
 		// Draw the graphics.
 		if t.graphics {
-			// Draw ancestor branches.
-			ancestor := node.parent
-			for ancestor != nil && ancestor.parent != nil && ancestor.parent.level >= t.topLevel {
-				if ancestor.graphicsX >= width {
-					continue
-				}
-
+			// Draw ancestor branches. The root may have a parent outside of
+			// this tree view which we ignore.
+			var ancestor *TreeNode
+			if node != t.root {
+				ancestor = node.parent
+			}
+			for ancestor != nil && ancestor != t.root && ancestor.parent != nil && ancestor.parent.level >= t.topLevel {
 				// Draw a branch if this ancestor is not a last child.
-				if ancestor.parent.children[len(ancestor.parent.children)-1] != ancestor {
+				if ancestor.graphicsX < width && ancestor.parent.children[len(ancestor.parent.children)-1] != ancestor {
 					if posY-1 >= y && ancestor.textX > ancestor.graphicsX {
 						PrintJoinedSemigraphics(screen, x+ancestor.graphicsX, posY-1, Borders.Vertical, lineStyle)
 					}
@@ -699,19 +993,59 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 
 		// Draw the prefix and the text.
 		if node.textX < width && posY < y+height {
+			// Determine the text style.
+			style := tcell.StyleDefault.Background(t.backgroundColor).Foreground(node.color)
+			if t.nodeStyle != nil {
+				foreground, background, attributes := t.nodeStyle(node).Decompose()
+				if foreground != tcell.ColorDefault {
+					style = style.Foreground(foreground)
+				}
+				if background != tcell.ColorDefault {
+					style = style.Background(background)
+				}
+				style = style.Attributes(attributes)
+			}
+			foreground, background, _ := style.Decompose()
+
 			// Prefix.
 			var prefixWidth int
 			if len(t.prefixes) > 0 {
-				_, prefixWidth = Print(screen, t.prefixes[(node.level-t.topLevel)%len(t.prefixes)], x+node.textX, posY, width-node.textX, AlignLeft, node.color)
+				_, prefixWidth = Print(screen, t.prefixes[(node.level-t.topLevel)%len(t.prefixes)], x+node.textX, posY, width-node.textX, AlignLeft, foreground)
 			}
 
-			// Text.
-			if node.textX+prefixWidth < width {
-				style := tcell.StyleDefault.Background(t.backgroundColor).Foreground(node.color)
-				if node == t.currentNode {
-					style = tcell.StyleDefault.Background(node.color).Foreground(t.backgroundColor)
+			// Highlight the current node, up to the right edge if it has a
+			// secondary text.
+			textX, textWidth := x+node.textX+prefixWidth, width-node.textX-prefixWidth
+			secondaryStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.secondaryTextColor)
+			if node == t.currentNode {
+				style = style.Background(foreground).Foreground(background)
+				if node.secondaryText != "" {
+					secondaryStyle = style
+					for column := textX; column < x+width; column++ {
+						screen.SetContent(column, posY, ' ', nil, style)
+					}
+				}
+			}
+
+			// Secondary text, pinned to the right edge.
+			if node.secondaryText != "" && textWidth > 0 {
+				secondaryWidth := TaggedStringWidth(node.secondaryText)
+				maxWidth := textWidth - 1 - TaggedStringWidth(node.text)
+				if half := (textWidth - 1) / 2; maxWidth < half {
+					maxWidth = half
+				}
+				if secondaryWidth > maxWidth {
+					secondaryWidth = maxWidth
 				}
-				printWithStyle(screen, node.text, x+node.textX+prefixWidth, posY, 0, width-node.textX-prefixWidth, AlignLeft, style, false)
+				if secondaryWidth > 0 {
+					printWithStyle(screen, node.secondaryText, x+width-secondaryWidth, posY, 0, secondaryWidth, AlignLeft, secondaryStyle, false)
+					textWidth -= secondaryWidth + 1
+				}
+			}
+
+			// Text.
+			if textWidth > 0 {
+				printWithStyle(screen, node.text, textX, posY, 0, textWidth, AlignLeft, style, false)
 			}
 		}
 
@@ -804,6 +1138,8 @@ func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 					if node.selected != nil {
 						node.selected()
 					}
+				} else if len(node.children) > 0 {
+					node.SetExpanded(!node.IsExpanded())
 				}
 			}
 			consumed = true
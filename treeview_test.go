@@ -0,0 +1,135 @@
+package tview
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/derailed/tcell/v2"
+)
+
+func TestTreeViewClickHeader(t *testing.T) {
+	header := NewTreeNode("header").
+		SetSelectable(false).
+		AddChild(NewTreeNode("a")).
+		AddChild(NewTreeNode("b"))
+	root := NewTreeNode("root").AddChild(header)
+	treeView := NewTreeView().SetRoot(root).SetCurrentNode(root)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 5)
+	treeView.SetRect(0, 0, 20, 5)
+	treeView.Draw(screen)
+	if rows := treeView.GetRowCount(); rows != 4 {
+		t.Fatalf("got %d rows before the click, want 4", rows)
+	}
+
+	for _, test := range []struct {
+		expanded bool
+		rows     int
+	}{
+		{expanded: false, rows: 2},
+		{expanded: true, rows: 4},
+	} {
+		treeView.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 1, tcell.ButtonPrimary, 0), func(p Primitive) {})
+		treeView.Draw(screen)
+		if header.IsExpanded() != test.expanded {
+			t.Errorf("header expanded is %t, want %t", header.IsExpanded(), test.expanded)
+		}
+		if rows := treeView.GetRowCount(); rows != test.rows {
+			t.Errorf("got %d rows, want %d", rows, test.rows)
+		}
+	}
+}
+
+// treeViewRows returns a description of the visible nodes of a tree view
+// after drawing it.
+func treeViewRows(treeView *TreeView, screen tcell.Screen) []string {
+	treeView.Draw(screen)
+	rows := make([]string, len(treeView.nodes))
+	for index, node := range treeView.nodes {
+		rows[index] = fmt.Sprintf("%s level=%d graphics=%d text=%d row=%d", node.text, node.level, node.graphicsX, node.textX, node.row)
+	}
+	return rows
+}
+
+func TestTreeViewIncrementalUpdate(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 10)
+
+	random := rand.New(rand.NewSource(1))
+	for _, setup := range []func(*TreeView){
+		func(treeView *TreeView) {},
+		func(treeView *TreeView) { treeView.SetTopLevel(1) },
+		func(treeView *TreeView) { treeView.SetAlign(true) },
+		func(treeView *TreeView) { treeView.SetGraphics(false).SetIndent(3) },
+	} {
+		root := NewTreeNode("root")
+		nodes := []*TreeNode{root}
+		newView := func() *TreeView {
+			treeView := NewTreeView().SetRoot(root)
+			treeView.SetRect(0, 0, 40, 10)
+			setup(treeView)
+			return treeView
+		}
+		treeView := newView()
+		for step := 0; step < 300; step++ {
+			treeViewRows(treeView, screen) // Build the rows (entirely after the comparison below).
+
+			// Change a random node.
+			node := nodes[random.Intn(len(nodes))]
+			switch random.Intn(6) {
+			case 0, 1:
+				child := NewTreeNode(fmt.Sprintf("n%d", step))
+				node.InsertChild(random.Intn(len(node.GetChildren())+1), child)
+				nodes = append(nodes, child)
+			case 2:
+				if children := node.GetChildren(); len(children) > 0 {
+					node.RemoveChild(children[random.Intn(len(children))])
+				}
+			case 3:
+				node.SetExpanded(!node.IsExpanded())
+			case 4:
+				node.SetIndent(random.Intn(4))
+			case 5:
+				if children := node.GetChildren(); len(children) > 0 {
+					grandchild := NewTreeNode(fmt.Sprintf("g%d", step))
+					children[0].AddChild(grandchild)
+					nodes = append(nodes, grandchild)
+				}
+			}
+
+			incremental := treeViewRows(treeView, screen)
+			full := treeViewRows(newView(), screen)
+			if fmt.Sprint(incremental) != fmt.Sprint(full) {
+				t.Fatalf("step %d: incremental update gives\n%s\nbut full rebuild gives\n%s", step, strings.Join(incremental, "\n"), strings.Join(full, "\n"))
+			}
+		}
+	}
+}
+
+func TestTreeViewWalkKeepsRows(t *testing.T) {
+	root := NewTreeNode("root").AddChild(NewTreeNode("a"))
+	treeView := NewTreeView().SetRoot(root)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	treeViewRows(treeView, screen)
+	nodes := treeView.nodes
+	root.Walk(func(node, parent *TreeNode) bool { return true })
+	treeViewRows(treeView, screen)
+	if &nodes[0] != &treeView.nodes[0] {
+		t.Error("Walk() caused the visible nodes to be rebuilt")
+	}
+}
@@ -18,6 +18,21 @@ const (
 	AlignRight
 )
 
+// Scroll bar visibility.
+const (
+	ScrollBarNever  = iota // Never show a scroll bar.
+	ScrollBarAuto          // Show a scroll bar only if the content does not fit.
+	ScrollBarAlways        // Always show a scroll bar.
+)
+
+// Sides of an anchor rectangle at which a popup is placed (see PopupRect()).
+const (
+	PopupBelow = iota
+	PopupAbove
+	PopupRight
+	PopupLeft
+)
+
 // Common regular expressions.
 var (
 	colorPattern     = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?:([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?:([lbdru]+|\-)?\]`)
@@ -666,3 +681,116 @@ func stripTags(text string) string {
 	})
 	return escapePattern.ReplaceAllString(stripped, `[$1$2]`)
 }
+
+// scrollBarThumb returns the position of a vertical scroll bar's thumb
+// relative to the top of the scroll bar as well as its height, given the
+// scroll bar's height, the total height of the content, and the index of the
+// first visible content row.
+func scrollBarThumb(height, contentHeight, offset int) (top, size int) {
+	if height <= 0 || contentHeight <= height {
+		return 0, height
+	}
+	size = height * height / contentHeight
+	if size < 1 {
+		size = 1
+	}
+	top = offset * (height - size) / (contentHeight - height)
+	if top < 0 {
+		top = 0
+	} else if top > height-size {
+		top = height - size
+	}
+	return
+}
+
+// scrollBarOffset returns the index of the first visible content row such that
+// the thumb of a vertical scroll bar is centered on the given row (relative to
+// the top of the scroll bar).
+func scrollBarOffset(row, height, contentHeight int) int {
+	_, size := scrollBarThumb(height, contentHeight, 0)
+	if height-size <= 0 {
+		return 0
+	}
+	offset := (row - size/2) * (contentHeight - height) / (height - size)
+	if offset < 0 {
+		offset = 0
+	} else if offset > contentHeight-height {
+		offset = contentHeight - height
+	}
+	return offset
+}
+
+// drawScrollBar draws a vertical scroll bar at column x, starting at row y with
+// the given height. The thumb is sized and positioned according to the total
+// height of the content and the index of the first visible content row.
+func drawScrollBar(screen tcell.Screen, x, y, height, contentHeight, offset int, trackColor, thumbColor, backgroundColor tcell.Color) {
+	top, size := scrollBarThumb(height, contentHeight, offset)
+	trackStyle := tcell.StyleDefault.Foreground(trackColor).Background(backgroundColor)
+	thumbStyle := tcell.StyleDefault.Foreground(thumbColor).Background(backgroundColor)
+	for row := 0; row < height; row++ {
+		if row >= top && row < top+size {
+			screen.SetContent(x, y+row, '█', nil, thumbStyle)
+		} else {
+			screen.SetContent(x, y+row, Borders.Vertical, nil, trackStyle)
+		}
+	}
+}
+
+// PopupRect calculates the position and size of a popup, e.g. a drop-down list
+// or a tooltip, which is to be placed next to an anchor rectangle (e.g. the
+// input area of a form item) on a screen of the given size. The popup is
+// placed on the preferred side of the anchor (one of the Popup constants), or
+// on the opposite side if it does not fit there and there is more space on
+// the other side. Along the anchor, the popup starts at the anchor's top-left
+// corner, shifted to stay on the screen. The popup is shrunk if it does not
+// fit on the screen.
+//
+// Within a Draw() function, the screen size can be obtained with
+// screen.Size().
+func PopupRect(anchorX, anchorY, anchorWidth, anchorHeight, width, height, side, screenWidth, screenHeight int) (x, y, w, h int) {
+	// place returns the start and size of the popup along one axis. "before"
+	// and "after" are the available space before and after the anchor.
+	place := func(start, size, length, total int, preferAfter bool) (int, int) {
+		before, after := start, total-start-size
+		if preferAfter && length > after && before > after || !preferAfter && (length <= before || before >= after) {
+			if length > before {
+				length = before
+			}
+			return start - length, length
+		}
+		if length > after {
+			length = after
+		}
+		return start + size, length
+	}
+
+	// align returns the start and size of the popup along the anchor.
+	align := func(start, length, total int) (int, int) {
+		if length > total {
+			length = total
+		}
+		if start+length > total {
+			start = total - length
+		}
+		if start < 0 {
+			start = 0
+		}
+		return start, length
+	}
+
+	switch side {
+	case PopupRight, PopupLeft:
+		x, w = place(anchorX, anchorWidth, width, screenWidth, side == PopupRight)
+		y, h = align(anchorY, height, screenHeight)
+	default:
+		y, h = place(anchorY, anchorHeight, height, screenHeight, side != PopupAbove)
+		x, w = align(anchorX, width, screenWidth)
+	}
+	if w < 0 {
+		w = 0
+	}
+	if h < 0 {
+		h = 0
+	}
+	return
+}
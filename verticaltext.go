@@ -0,0 +1,115 @@
+package tview
+
+import (
+	"github.com/derailed/tcell/v2"
+)
+
+// VerticalText displays a single line of text running from top to bottom, one
+// character per row. It is useful for labels in narrow spaces such as side
+// panels or tab spines. Color tags are not supported. Newlines are ignored.
+type VerticalText struct {
+	*Box
+
+	// The text to be displayed.
+	text string
+
+	// The text color.
+	textColor tcell.Color
+
+	// The horizontal alignment of the text column and the vertical alignment
+	// of the text within the box.
+	align, verticalAlign int
+}
+
+// NewVerticalText returns a new vertical text primitive with the given text.
+func NewVerticalText(text string) *VerticalText {
+	return &VerticalText{
+		Box:           NewBox(),
+		text:          text,
+		textColor:     Styles.PrimaryTextColor,
+		align:         AlignCenter,
+		verticalAlign: AlignLeft,
+	}
+}
+
+// SetText sets the text to be displayed.
+func (v *VerticalText) SetText(text string) *VerticalText {
+	v.text = text
+	return v
+}
+
+// GetText returns the text that is displayed.
+func (v *VerticalText) GetText() string {
+	return v.text
+}
+
+// SetTextColor sets the color of the text.
+func (v *VerticalText) SetTextColor(color tcell.Color) *VerticalText {
+	v.textColor = color
+	return v
+}
+
+// SetAlign sets the horizontal alignment of the text column within the box
+// ("align", one of AlignLeft, AlignCenter (the default), or AlignRight) and the
+// vertical alignment of the text ("verticalAlign", using the same constants
+// where AlignLeft (the default) means top and AlignRight means bottom).
+func (v *VerticalText) SetAlign(align, verticalAlign int) *VerticalText {
+	v.align, v.verticalAlign = align, verticalAlign
+	return v
+}
+
+// Draw draws this primitive onto the screen.
+func (v *VerticalText) Draw(screen tcell.Screen) {
+	v.Box.DrawForSubclass(screen, v)
+
+	x, y, width, height := v.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Split the text into characters.
+	type character struct {
+		main  rune
+		comb  []rune
+		width int
+	}
+	var characters []character
+	iterateString(v.text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		if main == '\n' || main == '\r' {
+			return false
+		}
+		characters = append(characters, character{main: main, comb: comb, width: screenWidth})
+		return false
+	})
+
+	// Align the text.
+	column := x
+	switch v.align {
+	case AlignCenter:
+		column += (width - 1) / 2
+	case AlignRight:
+		column += width - 1
+	}
+	row := y
+	switch v.verticalAlign {
+	case AlignCenter:
+		row += (height - len(characters)) / 2
+	case AlignRight:
+		row += height - len(characters)
+	}
+	if row < y {
+		row = y
+	}
+
+	// Draw the characters.
+	style := tcell.StyleDefault.Foreground(v.textColor).Background(v.backgroundColor)
+	for _, ch := range characters {
+		if row >= y+height {
+			break
+		}
+		if column+ch.width <= x+width {
+			screen.SetContent(column, row, ch.main, ch.comb, style)
+		}
+		row++
+	}
+}
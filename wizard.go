@@ -0,0 +1,440 @@
+package tview
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/derailed/tcell/v2"
+)
+
+// wizardStep represents one step of a Wizard.
+type wizardStep struct {
+	Title    string      // The step's title, shown in the step indicator.
+	Item     Primitive   // The step's primitive.
+	Validate func() bool // An optional function which decides whether the user may advance.
+}
+
+// Wizard guides the user through an ordered sequence of steps, e.g. for a setup
+// dialog. Only the current step is shown, with a step indicator ("Step 2 of 4:
+// Network") above it and a row of buttons below it: "Back" (except for the
+// first step), "Next" ("Finish" for the last step), and "Cancel" (if a cancel
+// handler was set with SetCancelFunc()). Each step may have a validation
+// function which must approve before the user can advance, e.g. to check the
+// input of a form.
+//
+// Steps are typically forms but can be any primitives. When a step is a form,
+// Tab on its last element moves the focus to the buttons and Tab on the last
+// button moves it back to the form (Backtab works the other way round). In
+// addition to using the buttons, the user can press Ctrl-N to advance and
+// Ctrl-P to go back from anywhere within the wizard. Pressing Escape while a
+// button has focus cancels the wizard.
+type Wizard struct {
+	*Box
+
+	// The steps, in the order in which they are shown.
+	steps []*wizardStep
+
+	// The index of the current step.
+	currentStep int
+
+	// The pages holding the steps' primitives, named after their indices.
+	pages *Pages
+
+	// The form holding the buttons.
+	form *Form
+
+	// The labels of the buttons.
+	backLabel, nextLabel, finishLabel, cancelLabel string
+
+	// The color of the step indicator.
+	indicatorColor tcell.Color
+
+	// An optional function which returns the text of the step indicator.
+	indicator func(step, count int, title string) string
+
+	// We keep a reference to the function which allows us to set the focus to
+	// the buttons after they were replaced.
+	setFocus func(p Primitive)
+
+	// An optional function which is called when the current step changes.
+	changed func(step int)
+
+	// An optional function which is called when the user finishes the last
+	// step.
+	finished func()
+
+	// An optional function which is called when the user cancels the wizard.
+	cancel func()
+}
+
+// NewWizard returns a new wizard without any steps.
+func NewWizard() *Wizard {
+	w := &Wizard{
+		Box:            NewBox(),
+		pages:          NewPages(),
+		backLabel:      "Back",
+		nextLabel:      "Next",
+		finishLabel:    "Finish",
+		cancelLabel:    "Cancel",
+		indicatorColor: Styles.SecondaryTextColor,
+	}
+	w.form = NewForm().
+		SetButtonsAlign(AlignRight).
+		SetWrapAround(false).
+		SetDoneFunc(w.focusStep)
+	w.form.SetBorderPadding(0, 0, 0, 0)
+	w.form.SetCancelFunc(func() {
+		if w.cancel != nil {
+			w.cancel()
+		}
+	})
+	w.updateButtons()
+	return w
+}
+
+// AddStep adds a step at the end of the wizard. The title is shown in the step
+// indicator and may contain color tags. If "validate" is not nil, it is called
+// when the user tries to advance from this step (including finishing the last
+// step). The user then only advances if it returns true. The function may
+// show an error message or move the focus to an invalid field before
+// returning false.
+//
+// If the item is a *Form, it no longer wraps around (see Form.SetWrapAround())
+// and its done handler is replaced with one which moves the focus to the
+// wizard's buttons.
+func (w *Wizard) AddStep(title string, item Primitive, validate func() bool) *Wizard {
+	if form, ok := item.(*Form); ok {
+		form.SetWrapAround(false).SetDoneFunc(w.focusButtons)
+	}
+	w.steps = append(w.steps, &wizardStep{
+		Title:    title,
+		Item:     item,
+		Validate: validate,
+	})
+	w.pages.AddPage(strconv.Itoa(len(w.steps)-1), item, true, len(w.steps) == 1)
+	w.updateButtons()
+	return w
+}
+
+// GetStepCount returns the number of steps.
+func (w *Wizard) GetStepCount() int {
+	return len(w.steps)
+}
+
+// GetStep returns the title and the primitive of the step with the given
+// index or ("", nil) if the index is out of range.
+func (w *Wizard) GetStep(index int) (title string, item Primitive) {
+	if index < 0 || index >= len(w.steps) {
+		return "", nil
+	}
+	return w.steps[index].Title, w.steps[index].Item
+}
+
+// GetCurrentStep returns the index of the current step.
+func (w *Wizard) GetCurrentStep() int {
+	return w.currentStep
+}
+
+// SetCurrentStep switches to the step with the given index without calling
+// any validation functions. Indices out of range are clamped.
+func (w *Wizard) SetCurrentStep(index int) *Wizard {
+	if index >= len(w.steps) {
+		index = len(w.steps) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	w.switchTo(index)
+	return w
+}
+
+// Next advances to the next step if the validation function of the current
+// step approves. On the last step, the finished handler is called instead
+// (see SetFinishedFunc()). Returns whether the user was allowed to advance.
+func (w *Wizard) Next() bool {
+	if len(w.steps) == 0 {
+		return false
+	}
+	if step := w.steps[w.currentStep]; step.Validate != nil && !step.Validate() {
+		return false
+	}
+	if w.currentStep == len(w.steps)-1 {
+		if w.finished != nil {
+			w.finished()
+		}
+		return true
+	}
+	w.switchTo(w.currentStep + 1)
+	return true
+}
+
+// Back returns to the previous step. The current step is not validated.
+// Nothing happens on the first step.
+func (w *Wizard) Back() *Wizard {
+	if w.currentStep > 0 {
+		w.switchTo(w.currentStep - 1)
+	}
+	return w
+}
+
+// SetButtonLabels sets the labels of the "Back", "Next", "Finish", and
+// "Cancel" buttons.
+func (w *Wizard) SetButtonLabels(back, next, finish, cancel string) *Wizard {
+	w.backLabel, w.nextLabel, w.finishLabel, w.cancelLabel = back, next, finish, cancel
+	w.updateButtons()
+	return w
+}
+
+// SetIndicatorColor sets the color of the step indicator.
+func (w *Wizard) SetIndicatorColor(color tcell.Color) *Wizard {
+	w.indicatorColor = color
+	return w
+}
+
+// SetIndicatorFunc sets a function which returns the text of the step
+// indicator. It receives the index of the current step (starting at 0), the
+// number of steps, and the title of the current step. The returned text may
+// contain color tags. An empty text hides the indicator. Provide nil to
+// restore the default ("Step 2 of 4: Title").
+func (w *Wizard) SetIndicatorFunc(handler func(step, count int, title string) string) *Wizard {
+	w.indicator = handler
+	return w
+}
+
+// GetForm returns the form which holds the wizard's buttons. Its buttons are
+// replaced whenever the current step changes so changes made to individual
+// buttons are lost. Its done handler must not be replaced.
+func (w *Wizard) GetForm() *Form {
+	return w.form
+}
+
+// SetChangedFunc sets a handler which is called when the current step changes,
+// either by the user or by calling SetCurrentStep(), Next(), or Back(). It
+// receives the index of the new step.
+func (w *Wizard) SetChangedFunc(handler func(step int)) *Wizard {
+	w.changed = handler
+	return w
+}
+
+// SetFinishedFunc sets a handler which is called when the user finishes the
+// last step, i.e. after its validation function approved.
+func (w *Wizard) SetFinishedFunc(handler func()) *Wizard {
+	w.finished = handler
+	return w
+}
+
+// SetCancelFunc sets a handler which is called when the user selects the
+// "Cancel" button or presses Escape while a button has focus. The "Cancel"
+// button is only shown if a handler is set. Provide nil to remove it.
+func (w *Wizard) SetCancelFunc(handler func()) *Wizard {
+	w.cancel = handler
+	w.updateButtons()
+	return w
+}
+
+// switchTo shows the step with the given index.
+func (w *Wizard) switchTo(index int) {
+	if index < 0 || index >= len(w.steps) {
+		return
+	}
+	changed := index != w.currentStep
+	hadFocus := w.HasFocus()
+	w.currentStep = index
+	w.pages.SwitchToPage(strconv.Itoa(index))
+	w.updateButtons()
+	if hadFocus && w.setFocus != nil && !w.form.HasFocus() {
+		w.setFocus(w.steps[index].Item)
+	}
+	if changed && w.changed != nil {
+		w.changed(index)
+	}
+}
+
+// navigatesForward returns whether the given navigation key (see
+// Form.SetDoneFunc()) moves the focus forward.
+func navigatesForward(key tcell.Key) bool {
+	switch key {
+	case tcell.KeyBacktab, tcell.KeyUp, tcell.KeyLeft:
+		return false
+	}
+	return true
+}
+
+// focusButtons moves the focus from the current step to the buttons, to the
+// "Next" or "Finish" button when navigating forward with the given key, to the
+// last button otherwise.
+func (w *Wizard) focusButtons(key tcell.Key) {
+	if w.setFocus == nil {
+		return
+	}
+	if navigatesForward(key) {
+		w.form.SetFocus(w.nextButton())
+	} else {
+		w.form.SetFocus(w.form.GetButtonCount() - 1)
+	}
+	w.setFocus(w.form)
+}
+
+// focusStep moves the focus from the buttons to the current step. If the step
+// is a form, its first element receives focus when navigating forward with the
+// given key, its last element otherwise.
+func (w *Wizard) focusStep(key tcell.Key) {
+	if w.setFocus == nil || len(w.steps) == 0 || w.steps[w.currentStep].Item == nil {
+		return
+	}
+	item := w.steps[w.currentStep].Item
+	if form, ok := item.(*Form); ok {
+		order := form.elementOrder()
+		if !navigatesForward(key) {
+			for index := len(order) - 1; index >= 0; index-- {
+				if order[index] < len(form.items) {
+					if _, ok := form.items[order[index]].(*formSection); ok {
+						continue
+					}
+				}
+				form.SetFocus(order[index])
+				break
+			}
+		} else if len(order) > 0 {
+			form.SetFocus(order[0])
+		}
+	}
+	w.setFocus(item)
+}
+
+// nextButton returns the index of the "Next" or "Finish" button.
+func (w *Wizard) nextButton() int {
+	if w.currentStep > 0 {
+		return 1 // After "Back".
+	}
+	return 0
+}
+
+// updateButtons replaces the buttons according to the current step. If a
+// button had focus, the "Next" or "Finish" button receives it.
+func (w *Wizard) updateButtons() {
+	hadFocus := w.form.HasFocus()
+	w.form.ClearButtons()
+	if w.currentStep > 0 {
+		w.form.AddButton(w.backLabel, func() {
+			w.Back()
+		})
+	}
+	label := w.nextLabel
+	if w.currentStep >= len(w.steps)-1 {
+		label = w.finishLabel
+	}
+	w.form.AddButton(label, func() {
+		w.Next()
+	})
+	if w.cancel != nil {
+		w.form.AddButton(w.cancelLabel, func() {
+			if w.cancel != nil {
+				w.cancel()
+			}
+		})
+	}
+	w.form.SetFocus(w.nextButton())
+	if hadFocus && w.setFocus != nil {
+		w.setFocus(w.form)
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (w *Wizard) Draw(screen tcell.Screen) {
+	w.Box.DrawForSubclass(screen, w)
+	x, y, width, height := w.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the step indicator.
+	if len(w.steps) > 0 {
+		step := w.steps[w.currentStep]
+		var text string
+		if w.indicator != nil {
+			text = w.indicator(w.currentStep, len(w.steps), step.Title)
+		} else {
+			text = fmt.Sprintf("Step %d of %d", w.currentStep+1, len(w.steps))
+			if step.Title != "" {
+				text += ": " + step.Title
+			}
+		}
+		if text != "" {
+			Print(screen, text, x, y, width, AlignLeft, w.indicatorColor)
+			y++
+			height--
+		}
+	}
+
+	// Draw the buttons and the current step.
+	if height > 0 {
+		w.form.SetRect(x, y+height-1, width, 1)
+		height--
+	}
+	w.pages.SetRect(x, y, width, height)
+	if w.form.HasFocus() {
+		w.pages.Draw(screen)
+		w.form.Draw(screen)
+	} else {
+		w.form.Draw(screen)
+		w.pages.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (w *Wizard) Focus(delegate func(p Primitive)) {
+	w.setFocus = delegate
+	if len(w.steps) > 0 && w.steps[w.currentStep].Item != nil {
+		delegate(w.steps[w.currentStep].Item)
+		return
+	}
+	delegate(w.form)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (w *Wizard) HasFocus() bool {
+	return w.pages.HasFocus() || w.form.HasFocus()
+}
+
+// InputHandler returns the handler for this primitive.
+func (w *Wizard) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return w.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		w.setFocus = setFocus
+		switch event.Key() {
+		case tcell.KeyCtrlN:
+			w.Next()
+			return
+		case tcell.KeyCtrlP:
+			w.Back()
+			return
+		}
+
+		if w.form.HasFocus() {
+			if handler := w.form.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+			return
+		}
+		if handler := w.pages.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (w *Wizard) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return w.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !w.InRect(event.Position()) {
+			return false, nil
+		}
+		w.setFocus = setFocus
+
+		// Pass mouse events along to the buttons, then to the current step.
+		consumed, capture = w.form.MouseHandler()(action, event, setFocus)
+		if consumed {
+			return
+		}
+		return w.pages.MouseHandler()(action, event, setFocus)
+	})
+}